@@ -0,0 +1,170 @@
+package msa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const auditRedacted = "[REDACTED]"
+
+var loginHashPattern = regexp.MustCompile(`/api/login/[0-9A-Za-z]+`)
+
+// AuditLog appends one JSON line per executed command. Each line goes to the
+// file in a single unbuffered write, so a provider that crashes or is killed
+// loses nothing. The log belongs to whoever opened it, which closes it when
+// it is no longer used; it is safe for concurrent use.
+type AuditLog struct {
+	path string
+
+	mu   sync.Mutex
+	file *os.File
+	now  func() time.Time
+}
+
+type auditEntry struct {
+	Time         string   `json:"time"`
+	Endpoint     string   `json:"endpoint"`
+	Username     string   `json:"username"`
+	Command      []string `json:"command"`
+	Status       string   `json:"status"`
+	ResponseType string   `json:"response_type,omitempty"`
+	ReturnCode   *int     `json:"return_code,omitempty"`
+	Error        string   `json:"error,omitempty"`
+}
+
+// OpenAuditLog opens path for appending.
+func OpenAuditLog(path string) (*AuditLog, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, errors.New("audit log path is required")
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolve audit log path %q: %w", path, err)
+	}
+	file, err := os.OpenFile(abs, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log %q: %w", abs, err)
+	}
+	return &AuditLog{path: abs, file: file, now: time.Now}, nil
+}
+
+// Close closes the file. Later records are dropped.
+func (l *AuditLog) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	if err != nil {
+		return fmt.Errorf("close audit log %q: %w", l.path, err)
+	}
+	return nil
+}
+
+func (l *AuditLog) record(entry auditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	entry.Time = l.now().UTC().Format(time.RFC3339Nano)
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode audit entry: %w", err)
+	}
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write audit log %q: %w", l.path, err)
+	}
+	return nil
+}
+
+// audit records the outcome of one command sent by Execute with credentials and
+// session keys removed from both the command and the error text.
+func (c *Client) audit(ctx context.Context, parts []string, err error) {
+	c.mu.Lock()
+	sessionKey := c.sessionKey
+	c.mu.Unlock()
+	c.auditSession(ctx, parts, err, sessionKey)
+}
+
+// auditSession is audit for callers that already hold c.mu and pass the
+// session key the command ran with. A line that cannot be written is logged
+// as a warning; the command's own result is unaffected.
+func (c *Client) auditSession(ctx context.Context, parts []string, err error, sessionKey string) {
+	log := c.config.AuditLog
+	if log == nil {
+		return
+	}
+
+	entry := auditEntry{
+		Endpoint: c.baseURL,
+		Username: c.username,
+		Command:  RedactCommand(parts),
+		Status:   "success",
+	}
+	if err != nil {
+		entry.Status = "error"
+		entry.Error = c.redactSecrets(err.Error(), sessionKey)
+		var apiErr APIError
+		if errors.As(err, &apiErr) {
+			code := apiErr.Status.ReturnCode
+			entry.ResponseType = apiErr.Status.ResponseType
+			entry.ReturnCode = &code
+		}
+	}
+	if writeErr := log.record(entry); writeErr != nil {
+		tflog.Warn(ctx, "Unable to write MSA audit log entry", map[string]any{
+			"command": strings.Join(entry.Command, " "),
+			"error":   writeErr.Error(),
+		})
+	}
+}
+
+func (c *Client) redactSecrets(text, sessionKey string) string {
+	for _, secret := range []string{c.password, sessionKey} {
+		if secret != "" {
+			text = strings.ReplaceAll(text, secret, auditRedacted)
+		}
+	}
+	return loginHashPattern.ReplaceAllString(text, "/api/login/"+auditRedacted)
+}
+
+// RedactCommand returns a copy of parts with the value following any
+// password, secret, or community keyword replaced.
+func RedactCommand(parts []string) []string {
+	redacted := make([]string, len(parts))
+	copy(redacted, parts)
+	for i := 0; i < len(redacted)-1; i++ {
+		if isSensitiveKeyword(redacted[i]) {
+			redacted[i+1] = auditRedacted
+			i++
+		}
+	}
+	return redacted
+}
+
+func isSensitiveKeyword(token string) bool {
+	token = strings.ToLower(strings.TrimSpace(token))
+	for _, keyword := range []string{"password", "secret", "community", "passphrase"} {
+		if token == keyword || strings.HasSuffix(token, "-"+keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package msa
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestExecuteWritesRedactedAuditLog(t *testing.T) {
+	commandOK := readFixture(t, "command_success.xml")
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/login/"):
+			_, _ = w.Write(loginResponse("session-abc123"))
+		case strings.HasPrefix(r.URL.Path, "/api/delete/"):
+			_, _ = w.Write(commandErrorResponse("The volume was not found on this system."))
+		default:
+			_, _ = w.Write(commandOK)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := OpenAuditLog(path)
+	if err != nil {
+		t.Fatalf("open audit log: %v", err)
+	}
+	client, err := NewClient(Config{
+		Endpoint:     server.URL,
+		Username:     "user",
+		Password:     "pass",
+		InsecureTLS:  true,
+		AuditLog:     auditLog,
+		ForceEnglish: true,
+	})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := client.Execute(ctx, "set", "user", "bob", "password", "S3cret!"); err != nil {
+		t.Fatalf("set user: %v", err)
+	}
+	if _, err := client.Execute(ctx, "delete", "volumes", "missing"); err == nil {
+		t.Fatalf("expected delete to fail")
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = client.Execute(ctx, "show", "system")
+		}()
+	}
+	wg.Wait()
+	if err := auditLog.Close(); err != nil {
+		t.Fatalf("close audit log: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read audit log: %v", err)
+	}
+	for _, secret := range []string{"S3cret!", "session-abc123"} {
+		if strings.Contains(string(raw), secret) {
+			t.Fatalf("audit log leaked %q: %s", secret, raw)
+		}
+	}
+
+	var entries []auditEntry
+	scanner := bufio.NewScanner(strings.NewReader(string(raw)))
+	for scanner.Scan() {
+		var entry auditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid audit line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 11 {
+		t.Fatalf("expected 11 audit lines, got %d", len(entries))
+	}
+
+	if !reflect.DeepEqual(entries[0].Command, englishLocaleCommand) || entries[0].Status != "success" {
+		t.Fatalf("expected the session locale to be audited first, got %+v", entries[0])
+	}
+	set := entries[1]
+	if !reflect.DeepEqual(set.Command, []string{"set", "user", "bob", "password", auditRedacted}) {
+		t.Fatalf("unexpected redacted command %v", set.Command)
+	}
+	if set.Status != "success" || set.Time == "" || set.Username != "user" {
+		t.Fatalf("unexpected success entry %+v", set)
+	}
+
+	failed := entries[2]
+	if failed.Status != "error" || failed.ResponseType != "Error" {
+		t.Fatalf("unexpected error entry %+v", failed)
+	}
+	if failed.ReturnCode == nil || *failed.ReturnCode != -1 {
+		t.Fatalf("expected return code -1, got %v", failed.ReturnCode)
+	}
+	if !strings.Contains(failed.Error, "not found") {
+		t.Fatalf("expected array error text, got %q", failed.Error)
+	}
+}
+
+func TestRedactCommand(t *testing.T) {
+	parts := []string{"create", "user", "password", "a", "auth-password", "b", "chap-secret", "c", "bob"}
+	got := RedactCommand(parts)
+	want := []string{"create", "user", "password", auditRedacted, "auth-password", auditRedacted, "chap-secret", auditRedacted, "bob"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if parts[3] != "a" {
+		t.Fatalf("RedactCommand must not modify its input")
+	}
+}
+
+func TestAuditLogRecordsHyphenationFallbackWithoutClose(t *testing.T) {
+	commandOK := readFixture(t, "command_success.xml")
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/login/"):
+			_, _ = w.Write(loginResponse("session-1"))
+		case strings.HasPrefix(r.URL.Path, "/api/create/host-group/"):
+			_, _ = w.Write(commandErrorResponse("Error: Unrecognized command."))
+		default:
+			_, _ = w.Write(commandOK)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := OpenAuditLog(path)
+	if err != nil {
+		t.Fatalf("open audit log: %v", err)
+	}
+	defer func() { _ = auditLog.Close() }()
+	client, err := NewClient(Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true, AuditLog: auditLog})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	if _, err := client.Execute(context.Background(), "create", "host-group", "hosts", "host-a", "cluster"); err != nil {
+		t.Fatalf("expected the hostgroup spelling to succeed, got %v", err)
+	}
+
+	// Read before Close: every line must already be on disk.
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read audit log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per attempt, got %q", raw)
+	}
+	var first, second auditEntry
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("invalid audit line %q: %v", lines[0], err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("invalid audit line %q: %v", lines[1], err)
+	}
+	if first.Command[1] != "host-group" || first.Status != "error" {
+		t.Fatalf("expected the rejected spelling to be logged as an error, got %+v", first)
+	}
+	if second.Command[1] != "hostgroup" || second.Status != "success" {
+		t.Fatalf("expected the alternate spelling that ran to be logged, got %+v", second)
+	}
+}
+
+func TestAuditLogRecordReportsWriteErrors(t *testing.T) {
+	auditLog, err := OpenAuditLog(filepath.Join(t.TempDir(), "audit.jsonl"))
+	if err != nil {
+		t.Fatalf("open audit log: %v", err)
+	}
+	// Close the file underneath the log so the next write fails.
+	if err := auditLog.file.Close(); err != nil {
+		t.Fatalf("close file: %v", err)
+	}
+
+	err = auditLog.record(auditEntry{Command: []string{"show", "system"}, Status: "success"})
+	if err == nil || !strings.Contains(err.Error(), "write audit log") {
+		t.Fatalf("expected a write error, got %v", err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
@@ -23,6 +24,19 @@ const (
 	defaultMaxAttempts = 3
 )
 
+// ErrResponseTooLarge is returned when a response exceeds the body size
+// limit; narrow the command (e.g. name a single object) instead.
+var ErrResponseTooLarge = fmt.Errorf("response exceeds %d bytes", maxBodySize)
+
+// ErrReadOnly is returned by Execute for any command other than show when
+// the client is configured read-only.
+var ErrReadOnly = errors.New("client is read-only")
+
+// errMissingStatus is returned when login/logout, which must report a status,
+// receive a response without one. Data commands treat a missing status as
+// success because several show variants omit it.
+var errMissingStatus = errors.New("management returned a response without a status object — unexpected firmware behavior")
+
 type Config struct {
 	Endpoint    string
 	Username    string
@@ -31,9 +45,39 @@ type Config struct {
 	Timeout     time.Duration
 	SessionTTL  time.Duration
 	Retry       RetryConfig
+	// AuditLog, when set, receives one line per Execute call. Derived
+	// connection clients share it.
+	AuditLog *AuditLog
+	// ForceEnglish sets the CLI locale of every new session to English so
+	// status messages can be matched regardless of the array's configured
+	// language. Firmware that rejects the setting keeps its default locale.
+	ForceEnglish bool
+	// ReadOnly makes Execute reject every verb except show before anything
+	// is sent to the array. It also skips the session locale set by
+	// ForceEnglish.
+	ReadOnly bool
+	// DefaultMappingAccess is the access level the provider applies to
+	// mappings that do not set one. The client only carries it.
+	DefaultMappingAccess string
+	// AllowedCommands and DeniedCommands restrict what Execute may run. Each
+	// entry is a verb ("delete") or a verb and object ("delete host"); "*"
+	// matches anything. Deny entries win, and when AllowedCommands is set only
+	// matching commands run. show commands are always allowed.
+	AllowedCommands []string
+	DeniedCommands  []string
+}
+
+// ConnectionOverride points a derived client at another array. Empty
+// credentials and a nil InsecureTLS inherit the parent client's values.
+type ConnectionOverride struct {
+	Endpoint    string
+	Username    string
+	Password    string
+	InsecureTLS *bool
 }
 
 type Client struct {
+	config      Config
 	baseURL     string
 	username    string
 	password    string
@@ -41,9 +85,15 @@ type Client struct {
 	retryConfig RetryConfig
 	sessionTTL  time.Duration
 
+	allowCommands []commandPattern
+	denyCommands  []commandPattern
+
 	mu           sync.Mutex
 	sessionKey   string
 	sessionUntil time.Time
+
+	derivedMu sync.Mutex
+	derived   map[string]*Client
 }
 
 func NewClient(cfg Config) (*Client, error) {
@@ -77,6 +127,15 @@ func NewClient(cfg Config) (*Client, error) {
 		sessionTTL = defaultSessionTTL
 	}
 
+	allowCommands, err := parseCommandPatterns(cfg.AllowedCommands)
+	if err != nil {
+		return nil, fmt.Errorf("allowed commands: %w", err)
+	}
+	denyCommands, err := parseCommandPatterns(cfg.DeniedCommands)
+	if err != nil {
+		return nil, fmt.Errorf("denied commands: %w", err)
+	}
+
 	transport := http.DefaultTransport.(*http.Transport).Clone()
 	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: cfg.InsecureTLS}
 
@@ -86,15 +145,66 @@ func NewClient(cfg Config) (*Client, error) {
 	}
 
 	return &Client{
+		config:      cfg,
 		baseURL:     endpoint,
 		username:    cfg.Username,
 		password:    cfg.Password,
 		httpClient:  client,
 		retryConfig: retryConfig,
 		sessionTTL:  sessionTTL,
+
+		allowCommands: allowCommands,
+		denyCommands:  denyCommands,
 	}, nil
 }
 
+// Username returns the account the client authenticates as.
+func (c *Client) Username() string {
+	return c.username
+}
+
+// DefaultMappingAccess returns the configured default mapping access, or ""
+// when none was set.
+func (c *Client) DefaultMappingAccess() string {
+	return c.config.DefaultMappingAccess
+}
+
+// WithConnection returns a client for the overridden endpoint that shares
+// this client's timeouts and retry settings. Derived clients are cached so
+// resources targeting the same array reuse one session.
+func (c *Client) WithConnection(override ConnectionOverride) (*Client, error) {
+	cfg := c.config
+	cfg.Endpoint = strings.TrimSpace(override.Endpoint)
+	if username := strings.TrimSpace(override.Username); username != "" {
+		cfg.Username = username
+	}
+	if override.Password != "" {
+		cfg.Password = override.Password
+	}
+	if override.InsecureTLS != nil {
+		cfg.InsecureTLS = *override.InsecureTLS
+	}
+
+	sum := sha256.Sum256([]byte(cfg.Password))
+	key := fmt.Sprintf("%s|%s|%x|%t", strings.TrimRight(cfg.Endpoint, "/"), cfg.Username, sum, cfg.InsecureTLS)
+
+	c.derivedMu.Lock()
+	defer c.derivedMu.Unlock()
+	if client, ok := c.derived[key]; ok {
+		return client, nil
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if c.derived == nil {
+		c.derived = make(map[string]*Client)
+	}
+	c.derived[key] = client
+	return client, nil
+}
+
 func (c *Client) Login(ctx context.Context) (string, error) {
 	for _, hash := range loginHashes(c.username, c.password) {
 		loginURL := fmt.Sprintf("%s/api/login/%s", c.baseURL, hash)
@@ -109,19 +219,20 @@ func (c *Client) Login(ctx context.Context) (string, error) {
 
 		response, err := parseResponse(body)
 		if err != nil {
-			return "", fmt.Errorf("login response parse failed: %w", err)
+			return "", fmt.Errorf("login response parse failed: %w", c.redactParseError(err, "", true))
 		}
 
 		statusObj, ok := response.Status()
 		if !ok {
-			return "", errors.New("login response missing status object")
+			return "", fmt.Errorf("login failed: %w", errMissingStatus)
 		}
 
-		if statusObj.Success() {
-			if statusObj.Response == "" {
+		if loginSucceeded(statusObj) {
+			sessionKey, ok := loginSessionKey(statusObj)
+			if !ok {
 				return "", errors.New("login response missing session key")
 			}
-			return statusObj.Response, nil
+			return sessionKey, nil
 		}
 
 		// If the hash variant doesn't match the array's expected format, the MSA
@@ -153,12 +264,12 @@ func (c *Client) Logout(ctx context.Context, sessionKey string) error {
 
 	response, err := parseResponse(body)
 	if err != nil {
-		return fmt.Errorf("logout response parse failed: %w", err)
+		return fmt.Errorf("logout response parse failed: %w", c.redactParseError(err, sessionKey, false))
 	}
 
 	statusObj, ok := response.Status()
 	if !ok {
-		return errors.New("logout response missing status object")
+		return fmt.Errorf("logout failed: %w", errMissingStatus)
 	}
 	if !statusObj.Success() {
 		return fmt.Errorf("logout failed: %s", statusObj.Response)
@@ -194,11 +305,12 @@ func (c *Client) Do(ctx context.Context, sessionKey, path string, query url.Valu
 
 	response, err := parseResponse(body)
 	if err != nil {
-		return Response{}, fmt.Errorf("response parse failed: %w", err)
+		return Response{}, fmt.Errorf("response parse failed: %w", c.redactParseError(err, sessionKey, false))
 	}
 
-	if statusObj, ok := response.Status(); ok && !statusObj.Success() {
-		return Response{}, APIError{Status: statusObj}
+	// A missing status object is treated as success for data commands.
+	if err := statusesError(response.Statuses()); err != nil {
+		return Response{}, err
 	}
 
 	return response, nil
@@ -208,7 +320,58 @@ func (c *Client) Command(ctx context.Context, sessionKey string, parts ...string
 	return c.Do(ctx, sessionKey, CommandPath(parts...), nil)
 }
 
+// Execute runs a command with the cached session, re-authenticating once on
+// session errors. Commands rejected as unknown are retried once with the
+// alternate hyphenation of their object (host-group vs hostgroup).
 func (c *Client) Execute(ctx context.Context, parts ...string) (Response, error) {
+	if err := c.checkCommand(parts); err != nil {
+		c.audit(ctx, parts, err)
+		return Response{}, err
+	}
+	return c.executeWithFallback(ctx, parts...)
+}
+
+// checkCommand applies the read-only mode and the command policy to one
+// spelling of a command before it is sent.
+func (c *Client) checkCommand(parts []string) error {
+	if c.config.ReadOnly && !isShowCommand(parts) {
+		return fmt.Errorf("%w: refusing to run %q", ErrReadOnly, strings.Join(RedactCommand(parts), " "))
+	}
+	return checkCommandPolicy(c.allowCommands, c.denyCommands, parts)
+}
+
+func isShowCommand(parts []string) bool {
+	return len(parts) > 0 && strings.EqualFold(strings.TrimSpace(parts[0]), "show")
+}
+
+// executeWithFallback audits every spelling it sends, so the log shows the
+// command the array actually ran. The alternate spelling passes the same
+// checks as the original before it is sent; a rejected alternate returns
+// the original error.
+func (c *Client) executeWithFallback(ctx context.Context, parts ...string) (Response, error) {
+	resp, err := c.execute(ctx, parts...)
+	c.audit(ctx, parts, err)
+	if err == nil || !isUnknownCommandError(err) {
+		return resp, err
+	}
+
+	alternate, ok := alternateHyphenation(parts)
+	if !ok {
+		return resp, err
+	}
+	if checkErr := c.checkCommand(alternate); checkErr != nil {
+		c.audit(ctx, alternate, checkErr)
+		return Response{}, err
+	}
+	altResp, altErr := c.execute(ctx, alternate...)
+	c.audit(ctx, alternate, altErr)
+	if altErr != nil {
+		return Response{}, err
+	}
+	return altResp, nil
+}
+
+func (c *Client) execute(ctx context.Context, parts ...string) (Response, error) {
 	sessionKey, err := c.ensureSession(ctx)
 	if err != nil {
 		return Response{}, err
@@ -231,6 +394,32 @@ func (c *Client) Execute(ctx context.Context, parts ...string) (Response, error)
 	return Response{}, err
 }
 
+// loginSucceeded judges a login status by its response type only. Login
+// return codes differ from command return codes (1 means authenticated on
+// most firmware, some report 0, and 2 means rejected), so Status.Success is
+// not reliable here. A response type other than Success/Error (e.g. a
+// localized word) is judged by response-type-numeric.
+func loginSucceeded(status Status) bool {
+	responseType := strings.TrimSpace(status.ResponseType)
+	switch {
+	case strings.EqualFold(responseType, "success"):
+		return true
+	case strings.EqualFold(responseType, "error"):
+		return false
+	}
+	return status.ResponseTypeNumeric == 0
+}
+
+// loginSessionKey returns the session key carried in a successful login
+// status. Free-form messages (anything with whitespace) are not keys.
+func loginSessionKey(status Status) (string, bool) {
+	sessionKey := strings.TrimSpace(status.Response)
+	if sessionKey == "" || strings.ContainsAny(sessionKey, " \t\r\n") {
+		return "", false
+	}
+	return sessionKey, true
+}
+
 func loginHashes(username, password string) []string {
 	// Some MSA firmware versions expect sha256("user_!pass") while others use
 	// sha256("user_pass"). Try both (most compatible).
@@ -247,10 +436,38 @@ func loginHash(username, password, delimiter string) string {
 
 func parseResponse(body []byte) (Response, error) {
 	var response Response
-	if err := xml.Unmarshal(body, &response); err != nil {
-		return Response{}, err
+	err := xml.Unmarshal(body, &response)
+	if err == nil {
+		return response, nil
 	}
-	return response, nil
+	// OEM firmware may wrap RESPONSE in another root element or emit HTML
+	// entities; retry with a lenient decoder before giving up.
+	if lenient, lenientErr := parseResponseLenient(body); lenientErr == nil {
+		return lenient, nil
+	}
+	return Response{}, newParseError(err, body)
+}
+
+// loginResponsePattern matches the status response property, which carries
+// the session key in login responses.
+var loginResponsePattern = regexp.MustCompile(`(?is)(<PROPERTY[^>]*\bname="response"[^>]*>)[^<]*`)
+
+// redactParseError removes the client's password and the request's session
+// key from a ParseError snippet, and for login responses the new session key
+// as well. It takes the key explicitly because login and the session locale
+// run while c.mu is held.
+func (c *Client) redactParseError(err error, sessionKey string, login bool) error {
+	var parseErr ParseError
+	if !errors.As(err, &parseErr) {
+		return err
+	}
+	body := c.redactSecrets(parseErr.body, sessionKey)
+	if login {
+		body = loginResponsePattern.ReplaceAllString(body, "${1}"+auditRedacted)
+	}
+	parseErr.Snippet = responseSnippet(body)
+	parseErr.body = ""
+	return parseErr
 }
 
 func (c *Client) ensureSession(ctx context.Context) (string, error) {
@@ -268,10 +485,33 @@ func (c *Client) ensureSession(ctx context.Context) (string, error) {
 
 	c.sessionKey = sessionKey
 	c.sessionUntil = time.Now().Add(c.sessionTTL)
+	if c.config.ForceEnglish {
+		c.setEnglishLocale(ctx, sessionKey)
+	}
 
 	return sessionKey, nil
 }
 
+// setEnglishLocale switches the session's CLI locale to English. The
+// setting only applies to this session, so the array's configured language
+// is left untouched. It is a set command like any other: a read-only client
+// skips it, the command policy can deny it, and the attempt is audited.
+// Failures are ignored: without it, messages stay localized and callers fall
+// back to code- and state-based checks.
+func (c *Client) setEnglishLocale(ctx context.Context, sessionKey string) {
+	if c.config.ReadOnly {
+		return
+	}
+	if err := checkCommandPolicy(c.allowCommands, c.denyCommands, englishLocaleCommand); err != nil {
+		c.auditSession(ctx, englishLocaleCommand, err, sessionKey)
+		return
+	}
+	_, err := c.Command(ctx, sessionKey, englishLocaleCommand...)
+	c.auditSession(ctx, englishLocaleCommand, err, sessionKey)
+}
+
+var englishLocaleCommand = []string{"set", "cli-parameters", "locale", "English"}
+
 func (c *Client) invalidateSession() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -290,8 +530,19 @@ func (c *Client) getWithRetry(ctx context.Context, url string, headers map[strin
 		lastBody = body
 		lastHeader = header
 		lastStatus = status
+		if errors.Is(err, ErrResponseTooLarge) {
+			return false, err
+		}
 		if err != nil {
-			return true, err
+			if IsTLSVerificationError(err) {
+				return false, fmt.Errorf("TLS certificate verification failed (set insecure_tls only for arrays with self-signed certificates): %w", err)
+			}
+			if isBrokenConnectionError(err) {
+				// Drop pooled connections so the retry performs a fresh
+				// handshake instead of reusing another stale connection.
+				c.httpClient.CloseIdleConnections()
+			}
+			return isRetryableTransportError(ctx, err), err
 		}
 		if isRetryableStatus(status) {
 			return true, fmt.Errorf("retryable HTTP status %d", status)
@@ -320,10 +571,13 @@ func (c *Client) get(ctx context.Context, url string, headers map[string]string)
 		_ = resp.Body.Close()
 	}()
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize+1))
 	if err != nil {
 		return nil, nil, resp.StatusCode, err
 	}
+	if len(body) > maxBodySize {
+		return nil, resp.Header, resp.StatusCode, ErrResponseTooLarge
+	}
 
 	return body, resp.Header, resp.StatusCode, nil
 }
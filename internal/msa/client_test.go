@@ -2,10 +2,15 @@ package msa
 
 import (
 	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -58,6 +63,115 @@ func TestLoginFailure(t *testing.T) {
 	}
 }
 
+func TestLoginMissingStatusObject(t *testing.T) {
+	fixture := readFixture(t, "show_no_status.xml")
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write(fixture)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	_, err := client.Login(context.Background())
+	if err == nil {
+		t.Fatalf("expected login error for status-less response")
+	}
+	if !errors.Is(err, errMissingStatus) {
+		t.Fatalf("expected missing status error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "unexpected firmware behavior") {
+		t.Fatalf("expected descriptive error, got %q", err.Error())
+	}
+
+	err = client.Logout(context.Background(), "session-1")
+	if !errors.Is(err, errMissingStatus) {
+		t.Fatalf("expected missing status error on logout, got %v", err)
+	}
+}
+
+func TestLoginReturnCodeConventions(t *testing.T) {
+	testCases := []struct {
+		name         string
+		responseType string
+		numeric      string
+		response     string
+		returnCode   string
+		wantKey      string
+		wantErr      bool
+	}{
+		{name: "success return-code 1", responseType: "Success", numeric: "0", response: "key-1", returnCode: "1", wantKey: "key-1"},
+		{name: "success return-code 0", responseType: "Success", numeric: "0", response: "key-0", returnCode: "0", wantKey: "key-0"},
+		{name: "success unexpected return-code", responseType: "Success", numeric: "0", response: "key-7", returnCode: "7", wantKey: "key-7"},
+		{name: "numeric only success", responseType: "", numeric: "0", response: "key-n", returnCode: "1", wantKey: "key-n"},
+		{name: "success without key", responseType: "Success", numeric: "0", response: "", returnCode: "1", wantErr: true},
+		{name: "success with message instead of key", responseType: "Success", numeric: "0", response: "Command completed successfully.", returnCode: "1", wantErr: true},
+		{name: "rejected return-code 2", responseType: "Error", numeric: "1", response: "Invalid sessionkey", returnCode: "2", wantErr: true},
+		{name: "error with return-code 1", responseType: "Error", numeric: "1", response: "Invalid credentials", returnCode: "1", wantErr: true},
+		{name: "localized success", responseType: "Erfolg", numeric: "0", response: "key-de", returnCode: "1", wantKey: "key-de"},
+		{name: "localized error", responseType: "Fehler", numeric: "1", response: "Ungültige Anmeldedaten", returnCode: "2", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<RESPONSE VERSION="L100">
+  <OBJECT basetype="status" name="status" oid="1">
+    <PROPERTY name="response-type" type="string">` + tc.responseType + `</PROPERTY>
+    <PROPERTY name="response-type-numeric" type="uint32">` + tc.numeric + `</PROPERTY>
+    <PROPERTY name="response" type="string">` + tc.response + `</PROPERTY>
+    <PROPERTY name="return-code" type="sint32">` + tc.returnCode + `</PROPERTY>
+  </OBJECT>
+</RESPONSE>`)
+
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/xml")
+				_, _ = w.Write(body)
+			}))
+			defer server.Close()
+
+			client := newTestClient(t, server.URL)
+			key, err := client.Login(context.Background())
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected login error, got key %q", key)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("login failed: %v", err)
+			}
+			if key != tc.wantKey {
+				t.Fatalf("expected key %q, got %q", tc.wantKey, key)
+			}
+		})
+	}
+}
+
+func TestDoMissingStatusObjectIsSuccess(t *testing.T) {
+	fixture := readFixture(t, "show_no_status.xml")
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write(fixture)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+
+	response, err := client.Do(context.Background(), "abc123", "/api/show/pools", nil)
+	if err != nil {
+		t.Fatalf("expected success for status-less data response, got %v", err)
+	}
+	if _, ok := response.Status(); ok {
+		t.Fatalf("expected fixture without status object")
+	}
+	if len(response.ObjectsWithoutStatus()) != 1 {
+		t.Fatalf("expected pool object, got %d objects", len(response.ObjectsWithoutStatus()))
+	}
+}
+
 func TestDoSendsSessionKey(t *testing.T) {
 	fixture := readFixture(t, "command_success.xml")
 
@@ -119,6 +233,341 @@ func TestDoRetriesOn503(t *testing.T) {
 	}
 }
 
+func TestDoStopsRetryingWhenContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	callCount := 0
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.retryConfig = RetryConfig{
+		MaxAttempts: 5,
+		MinBackoff:  10 * time.Second,
+		MaxBackoff:  10 * time.Second,
+		Jitter:      0,
+	}
+
+	start := time.Now()
+	_, err := client.Do(ctx, "abc123", "/api/show/system", url.Values{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context cancellation, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected prompt return after cancellation, took %s", elapsed)
+	}
+	if callCount != 1 {
+		t.Fatalf("expected a single attempt, got %d", callCount)
+	}
+}
+
+func TestDoReportsPartialFailure(t *testing.T) {
+	fixture := readFixture(t, "add_host_group_members_partial.xml")
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write(fixture)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	_, err := client.Do(context.Background(), "abc123", CommandPath("add", "host-group-members", "hosts", "HostB,HostC", "Group1"), url.Values{})
+
+	var partial PartialFailureError
+	if !errors.As(err, &partial) {
+		t.Fatalf("expected partial failure, got %T %v", err, err)
+	}
+	if len(partial.Succeeded()) != 1 || len(partial.Failed()) != 1 {
+		t.Fatalf("unexpected split succeeded=%d failed=%d", len(partial.Succeeded()), len(partial.Failed()))
+	}
+	if !strings.Contains(err.Error(), "HostC is already a member") || strings.Contains(err.Error(), "HostB was added") {
+		t.Fatalf("expected only the failed item in the message, got %q", err.Error())
+	}
+	var apiErr APIError
+	if !errors.As(err, &apiErr) || apiErr.Status.ReturnCode != -10033 {
+		t.Fatalf("expected first failure to unwrap as APIError, got %v", apiErr)
+	}
+}
+
+func TestStatusesError(t *testing.T) {
+	ok := Status{ResponseType: "Success"}
+	bad := Status{ResponseType: "Error", ResponseTypeNumeric: 1, Response: "boom", ReturnCode: -1}
+
+	if err := statusesError(nil); err != nil {
+		t.Fatalf("expected no statuses to succeed, got %v", err)
+	}
+	if err := statusesError([]Status{ok, ok}); err != nil {
+		t.Fatalf("expected all-success to succeed, got %v", err)
+	}
+	var partial PartialFailureError
+	if err := statusesError([]Status{bad, bad}); errors.As(err, &partial) {
+		t.Fatalf("expected a plain APIError when every status failed, got %v", err)
+	}
+	if err := statusesError([]Status{ok, bad}); !errors.As(err, &partial) {
+		t.Fatalf("expected partial failure, got %v", err)
+	}
+}
+
+// flakyListener drops the first accepted connections before the TLS
+// handshake completes, simulating a transient handshake failure.
+type flakyListener struct {
+	net.Listener
+	mu       sync.Mutex
+	drops    int
+	accepted int
+}
+
+func (l *flakyListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		l.mu.Lock()
+		l.accepted++
+		drop := l.drops > 0
+		if drop {
+			l.drops--
+		}
+		l.mu.Unlock()
+		if !drop {
+			return conn, nil
+		}
+		_ = conn.Close()
+	}
+}
+
+func (l *flakyListener) acceptedCount() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.accepted
+}
+
+func TestDoRetriesTLSHandshakeFailureOnFreshConnection(t *testing.T) {
+	fixture := readFixture(t, "command_success.xml")
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write(fixture)
+	}))
+	listener := &flakyListener{Listener: server.Listener, drops: 1}
+	server.Listener = listener
+	server.StartTLS()
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.retryConfig = RetryConfig{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+		Jitter:      0,
+	}
+
+	if _, err := client.Do(context.Background(), "abc123", "/api/show/system", url.Values{}); err != nil {
+		t.Fatalf("expected retry after handshake failure to succeed, got %v", err)
+	}
+	if got := listener.acceptedCount(); got != 2 {
+		t.Fatalf("expected a fresh connection for the retry (2 accepts), got %d", got)
+	}
+}
+
+func TestDoDoesNotRetryCertificateVerificationFailure(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	listener := &flakyListener{Listener: server.Listener}
+	server.Listener = listener
+	server.StartTLS()
+	defer server.Close()
+
+	client, err := NewClient(Config{
+		Endpoint: server.URL,
+		Username: "user",
+		Password: "pass",
+		Retry: RetryConfig{
+			MaxAttempts: 3,
+			MinBackoff:  time.Millisecond,
+			MaxBackoff:  time.Millisecond,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	_, err = client.Do(context.Background(), "abc123", "/api/show/system", url.Values{})
+	if err == nil {
+		t.Fatalf("expected certificate verification error")
+	}
+	if !IsTLSVerificationError(err) {
+		t.Fatalf("expected TLS verification classification, got %v", err)
+	}
+	if got := listener.acceptedCount(); got != 1 {
+		t.Fatalf("expected a single attempt for certificate errors, got %d", got)
+	}
+}
+
+func TestDoRejectsOversizedResponse(t *testing.T) {
+	callCount := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte("<RESPONSE>"))
+		_, _ = w.Write([]byte(strings.Repeat(" ", maxBodySize)))
+		_, _ = w.Write([]byte("</RESPONSE>"))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	_, err := client.Do(context.Background(), "abc123", "/api/show/volume-statistics", url.Values{})
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("expected response too large error, got %v", err)
+	}
+	if callCount != 1 {
+		t.Fatalf("expected oversized responses not to be retried, got %d calls", callCount)
+	}
+}
+
+func TestDoReportsBodySnippetOnParseFailure(t *testing.T) {
+	malformed := `<RESPONSE VERSION="L100"><OBJECT basetype="users" name="user">` +
+		`<PROPERTY name="username">admin</PROPERTY><PROPERTY name="password">hunter2</PROPERTY>` +
+		`<PROPERTY name="note">echo S3cr3t-pw</PROPERTY><PROPERTY name="broken"</OBJECT>` + strings.Repeat("<!-- padding -->", 100)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(malformed))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Endpoint: server.URL, Username: "user", Password: "S3cr3t-pw", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	_, err = client.Do(context.Background(), "abc123", "/api/show/users", url.Values{})
+
+	var parseErr ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected a ParseError, got %v", err)
+	}
+	if parseErr.Size != len(malformed) {
+		t.Fatalf("expected body size %d, got %d", len(malformed), parseErr.Size)
+	}
+	if !strings.HasPrefix(parseErr.Snippet, `<RESPONSE VERSION="L100"><OBJECT basetype="users"`) || !strings.Contains(err.Error(), `<PROPERTY name=\"username\">admin`) {
+		t.Fatalf("expected the error to contain a body excerpt, got %v", err)
+	}
+	if len(parseErr.Snippet) > parseErrorSnippetSize+len("...") || !strings.HasSuffix(parseErr.Snippet, "...") {
+		t.Fatalf("expected a truncated snippet, got %d bytes", len(parseErr.Snippet))
+	}
+	for _, secret := range []string{"hunter2", "S3cr3t-pw"} {
+		if strings.Contains(err.Error(), secret) {
+			t.Fatalf("expected %q to be redacted, got %v", secret, err)
+		}
+	}
+}
+
+func TestDoRedactsSecretsCutBySnippetTruncation(t *testing.T) {
+	// The password straddles the snippet limit, and a newline inside the
+	// body would shift it under whitespace collapsing.
+	prefix := `<RESPONSE VERSION="L100"><OBJECT basetype="status" name="status"><PROPERTY name="note">`
+	malformed := prefix + "\n" + strings.Repeat("x", parseErrorSnippetSize-len(prefix)-4) + "S3cr3t-pw</PROPERTY><PROPERTY"
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(malformed))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Endpoint: server.URL, Username: "user", Password: "S3cr3t-pw", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	_, err = client.Do(context.Background(), "abc123", "/api/show/users", url.Values{})
+
+	var parseErr ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected a ParseError, got %v", err)
+	}
+	if strings.Contains(parseErr.Snippet, "S3c") {
+		t.Fatalf("expected no part of the password in the snippet, got %q", parseErr.Snippet)
+	}
+}
+
+func TestLoginParseFailureRedactsSessionKey(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(`<RESPONSE><OBJECT basetype="status" name="status"><PROPERTY name="response">0123456789abcdef</PROPERTY><PROPERTY name="return-code">1</PROPERTY>`))
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(t, server.URL).Login(context.Background())
+	var parseErr ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected a ParseError, got %v", err)
+	}
+	if strings.Contains(err.Error(), "0123456789abcdef") || !strings.Contains(parseErr.Snippet, `<PROPERTY name="response">[REDACTED]`) {
+		t.Fatalf("expected the session key to be redacted, got %v", err)
+	}
+}
+
+func TestDoRetriesPerRequestTimeout(t *testing.T) {
+	fixture := readFixture(t, "command_success.xml")
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		first := requests == 1
+		mu.Unlock()
+		if first {
+			<-r.Context().Done()
+			return
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write(fixture)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.httpClient.Timeout = 100 * time.Millisecond
+	client.retryConfig = RetryConfig{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	if _, err := client.Do(context.Background(), "abc123", "/api/show/system", url.Values{}); err != nil {
+		t.Fatalf("expected the request to be retried after the client timeout, got %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestDoStopsRetryingWhenContextExpires(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.retryConfig = RetryConfig{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := client.Do(ctx, "abc123", "/api/show/system", url.Values{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the caller's deadline to be reported, got %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != 1 {
+		t.Fatalf("expected no retry after the caller's deadline, got %d requests", requests)
+	}
+}
+
 func TestExecuteRetriesOnSessionError(t *testing.T) {
 	commandOK := readFixture(t, "command_success.xml")
 	commandError := readFixture(t, "session_error.xml")
@@ -162,6 +611,288 @@ func TestExecuteRetriesOnSessionError(t *testing.T) {
 	}
 }
 
+func TestExecuteClassifiesLocalizedStatusesByCode(t *testing.T) {
+	localizedOK := readFixture(t, "command_success_localized.xml")
+	localizedError := readFixture(t, "create_volume_localized_error.xml")
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/login/"):
+			_, _ = w.Write(loginResponse("session-1"))
+		case strings.HasSuffix(r.URL.Path, "/vol-ok"):
+			_, _ = w.Write(localizedOK)
+		default:
+			_, _ = w.Write(localizedError)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.retryConfig = RetryConfig{MaxAttempts: 1}
+
+	if _, err := client.Execute(context.Background(), "create", "volume", "vol-ok"); err != nil {
+		t.Fatalf("expected localized success to be classified by code, got %v", err)
+	}
+
+	_, err := client.Execute(context.Background(), "create", "volume", "vol-dup")
+	var apiErr APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError for localized failure, got %v", err)
+	}
+	if apiErr.Status.ReturnCode != -10016 || IsSessionError(err) || IsPermissionDenied(err) {
+		t.Fatalf("unexpected classification of localized failure %+v", apiErr)
+	}
+}
+
+func TestExecuteForcesEnglishLocale(t *testing.T) {
+	commandOK := readFixture(t, "command_success.xml")
+
+	var mu sync.Mutex
+	paths := make([]string, 0)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		if strings.HasPrefix(r.URL.Path, "/api/login/") {
+			_, _ = w.Write(loginResponse("session-1"))
+			return
+		}
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		if strings.Contains(r.URL.Path, "cli-parameters") {
+			_, _ = w.Write(commandErrorResponse("Unbekannter Parameter"))
+			return
+		}
+		_, _ = w.Write(commandOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{
+		Endpoint:     server.URL,
+		Username:     "user",
+		Password:     "pass",
+		InsecureTLS:  true,
+		ForceEnglish: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	client.retryConfig = RetryConfig{MaxAttempts: 1}
+
+	if _, err := client.Execute(context.Background(), "show", "system"); err != nil {
+		t.Fatalf("expected a rejected locale setting to be ignored, got %v", err)
+	}
+	if _, err := client.Execute(context.Background(), "show", "system"); err != nil {
+		t.Fatalf("second command failed: %v", err)
+	}
+
+	want := []string{"/api/set/cli-parameters/locale/English", "/api/show/system", "/api/show/system"}
+	if strings.Join(paths, "|") != strings.Join(want, "|") {
+		t.Fatalf("expected locale to be set once per session, got %v", paths)
+	}
+}
+
+func TestExecuteReadOnlyBlocksMutatingVerbs(t *testing.T) {
+	commandOK := readFixture(t, "command_success.xml")
+
+	var mu sync.Mutex
+	paths := make([]string, 0)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		if strings.HasPrefix(r.URL.Path, "/api/login/") {
+			_, _ = w.Write(loginResponse("session-1"))
+			return
+		}
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		_, _ = w.Write(commandOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{
+		Endpoint:     server.URL,
+		Username:     "user",
+		Password:     "pass",
+		InsecureTLS:  true,
+		ReadOnly:     true,
+		ForceEnglish: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	client.retryConfig = RetryConfig{MaxAttempts: 1}
+
+	blocked := [][]string{
+		{"create", "volume", "vol01", "pool", "A", "size", "10GB"},
+		{"delete", "volumes", "vol01"},
+		{"set", "initiator", "id", "iqn.1991-05.com.example:host-a", "nickname", "host-a"},
+		{"map", "volume", "vol01", "initiator", "host-a", "lun", "1"},
+		{"create", "user", "audit", "password", "S3cret!"},
+	}
+	for _, parts := range blocked {
+		_, err := client.Execute(context.Background(), parts...)
+		if !errors.Is(err, ErrReadOnly) {
+			t.Fatalf("expected %v to be blocked, got %v", parts, err)
+		}
+		if strings.Contains(err.Error(), "S3cret!") {
+			t.Fatalf("read-only error leaked a secret: %v", err)
+		}
+	}
+	if _, err := client.Execute(context.Background(), "show", "volumes"); err != nil {
+		t.Fatalf("expected show to succeed in read-only mode, got %v", err)
+	}
+
+	if strings.Join(paths, "|") != "/api/show/volumes" {
+		t.Fatalf("expected only the show command to reach the array, not even the session locale, got %v", paths)
+	}
+}
+
+func TestExecuteDoesNotRetryPermissionDenied(t *testing.T) {
+	loginCalls := 0
+	commandCalls := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/login/"):
+			loginCalls++
+			_, _ = w.Write(loginResponse("session-1"))
+		case r.URL.Path == "/api/delete/volumes/vol01":
+			commandCalls++
+			_, _ = w.Write(commandErrorResponse("The user does not have permission to perform this operation. (authorization)"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.sessionTTL = time.Minute
+
+	_, err := client.Execute(context.Background(), "delete", "volumes", "vol01")
+	if err == nil {
+		t.Fatalf("expected permission error")
+	}
+	if !IsPermissionDenied(err) {
+		t.Fatalf("expected permission-denied classification, got %v", err)
+	}
+	if IsSessionError(err) {
+		t.Fatalf("permission error must not be treated as a session error")
+	}
+	if !strings.Contains(err.Error(), "lacks the 'manage' role") {
+		t.Fatalf("expected role hint in error, got %q", err.Error())
+	}
+	if commandCalls != 1 {
+		t.Fatalf("expected a single command attempt, got %d", commandCalls)
+	}
+	if loginCalls != 1 {
+		t.Fatalf("expected a single login, got %d", loginCalls)
+	}
+}
+
+func TestExecuteFallsBackToAlternateHyphenation(t *testing.T) {
+	var paths []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/login/"):
+			_, _ = w.Write(loginResponse("session-1"))
+		case r.URL.Path == "/api/show/host-groups":
+			paths = append(paths, r.URL.Path)
+			_, _ = w.Write(commandErrorResponse("Error: Invalid command. - The command is not recognized."))
+		case r.URL.Path == "/api/show/hostgroups":
+			paths = append(paths, r.URL.Path)
+			_, _ = w.Write(readFixture(t, "show_host_groups.xml"))
+		case r.URL.Path == "/api/delete/host-groups/missing":
+			paths = append(paths, r.URL.Path)
+			_, _ = w.Write(commandErrorResponse("The host group was not found."))
+		case r.URL.Path == "/api/delete/host-groups/old":
+			paths = append(paths, r.URL.Path)
+			_, _ = w.Write(commandErrorResponse("Error: Invalid command. - The command is not recognized."))
+		case r.URL.Path == "/api/delete/hostgroups/old":
+			paths = append(paths, r.URL.Path)
+			_, _ = w.Write(readFixture(t, "command_success.xml"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server.URL)
+	client.sessionTTL = time.Minute
+
+	response, err := client.Execute(context.Background(), "show", "host-groups")
+	if err != nil {
+		t.Fatalf("expected fallback to succeed, got %v", err)
+	}
+	if len(HostGroupsFromResponse(response)) == 0 {
+		t.Fatalf("expected host groups from fallback response")
+	}
+	if strings.Join(paths, ",") != "/api/show/host-groups,/api/show/hostgroups" {
+		t.Fatalf("unexpected command sequence %v", paths)
+	}
+
+	paths = nil
+	_, err = client.Execute(context.Background(), "delete", "host-groups", "missing")
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected original error without fallback, got %v", err)
+	}
+	if len(paths) != 1 {
+		t.Fatalf("expected no fallback for non-command errors, got %v", paths)
+	}
+
+	// The alternate spelling passes the same policy check before it is sent.
+	paths = nil
+	client.allowCommands, _ = parseCommandPatterns([]string{"delete host-group"})
+	if _, err := client.Execute(context.Background(), "delete", "host-groups", "old"); err != nil {
+		t.Fatalf("expected the allowed alternate to run, got %v", err)
+	}
+	if strings.Join(paths, ",") != "/api/delete/host-groups/old,/api/delete/hostgroups/old" {
+		t.Fatalf("unexpected command sequence with a policy %v", paths)
+	}
+}
+
+func TestAlternateHyphenation(t *testing.T) {
+	got, ok := alternateHyphenation([]string{"add", "host-group-members", "hosts", "h1", "hg1"})
+	if !ok || strings.Join(got, " ") != "add hostgroup-members hosts h1 hg1" {
+		t.Fatalf("unexpected alternate %v (%v)", got, ok)
+	}
+	got, ok = alternateHyphenation([]string{"create host", "host-group", "hg1", "h1"})
+	if ok {
+		t.Fatalf("expected parameters to be left untouched, got %v", got)
+	}
+	if _, ok := alternateHyphenation([]string{"show", "volumes"}); ok {
+		t.Fatalf("expected no alternate for volumes")
+	}
+}
+
+func TestIsPermissionDenied(t *testing.T) {
+	tests := []struct {
+		name       string
+		message    string
+		returnCode int
+		want       bool
+	}{
+		{name: "does not have permission", message: "The user does not have permission to execute this command.", returnCode: -1, want: true},
+		{name: "insufficient privileges", message: "Error: Insufficient privileges for the requested operation.", returnCode: -1, want: true},
+		{name: "access denied", message: "Access denied.", returnCode: -1, want: true},
+		{name: "generic code zero", message: "Access denied.", returnCode: 0, want: true},
+		{name: "session expired", message: "Invalid session key.", returnCode: -1, want: false},
+		{name: "generic failure", message: "The volume name is already in use.", returnCode: -1, want: false},
+		{name: "specific code with access wording", message: "Error: Access denied: the host already has read-write access to this LUN.", returnCode: -10016, want: false},
+		{name: "specific code with permission wording", message: "The user does not have permission to execute this command.", returnCode: -10033, want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := newAPIError(Status{ResponseType: "Error", ResponseTypeNumeric: 1, Response: tc.message, ReturnCode: tc.returnCode})
+			if got := IsPermissionDenied(err); got != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestFindActiveVolumeCopyJobWithETA(t *testing.T) {
 	fixture := readFixture(t, "show_volume_copy_active_eta.xml")
 
@@ -198,6 +929,9 @@ func TestFindActiveVolumeCopyJobWithETA(t *testing.T) {
 	if job.ETA != 2*time.Minute {
 		t.Fatalf("expected 2m ETA, got %s", job.ETA)
 	}
+	if !job.HasProgress || job.Progress != 51 {
+		t.Fatalf("expected 51%% progress, got %v (reported %t)", job.Progress, job.HasProgress)
+	}
 }
 
 func TestFindActiveVolumeCopyJobWithoutETA(t *testing.T) {
@@ -353,6 +1087,57 @@ func TestParseVolumeCopyETA(t *testing.T) {
 	}
 }
 
+func TestWithConnectionTargetsOverrideEndpoint(t *testing.T) {
+	primaryCalls := 0
+	primary := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryCalls++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer primary.Close()
+
+	var overridePaths []string
+	override := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		if strings.HasPrefix(r.URL.Path, "/api/login/") {
+			if r.URL.Path != "/api/login/"+loginHash("user", "other-pass", "_!") {
+				_, _ = w.Write(commandErrorResponse("Authentication Unsuccessful"))
+				return
+			}
+			_, _ = w.Write(loginResponse("session-b"))
+			return
+		}
+		overridePaths = append(overridePaths, r.URL.Path)
+		_, _ = w.Write(readFixture(t, "command_success.xml"))
+	}))
+	defer override.Close()
+
+	client := newTestClient(t, primary.URL)
+	derived, err := client.WithConnection(ConnectionOverride{Endpoint: override.URL, Password: "other-pass"})
+	if err != nil {
+		t.Fatalf("derive client: %v", err)
+	}
+	if derived.Username() != "user" {
+		t.Fatalf("expected username to be inherited, got %q", derived.Username())
+	}
+	if _, err := derived.Execute(context.Background(), "show", "system"); err != nil {
+		t.Fatalf("execute against override: %v", err)
+	}
+	if primaryCalls != 0 {
+		t.Fatalf("expected no calls to the provider endpoint, got %d", primaryCalls)
+	}
+	if len(overridePaths) != 1 || overridePaths[0] != "/api/show/system" {
+		t.Fatalf("unexpected override calls %v", overridePaths)
+	}
+
+	again, err := client.WithConnection(ConnectionOverride{Endpoint: override.URL + "/", Password: "other-pass"})
+	if err != nil {
+		t.Fatalf("derive client again: %v", err)
+	}
+	if again != derived {
+		t.Fatalf("expected derived clients to be cached per connection")
+	}
+}
+
 func TestCommandPath(t *testing.T) {
 	path := CommandPath("show", "pools")
 	if path != "/api/show/pools" {
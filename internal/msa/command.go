@@ -1,6 +1,9 @@
 package msa
 
-import "strings"
+import (
+	"errors"
+	"strings"
+)
 
 // CommandPath converts CLI-style commands into the XML API path.
 // Example: CommandPath("show", "pools") => "/api/show/pools".
@@ -15,3 +18,57 @@ func CommandPath(parts ...string) string {
 	}
 	return "/" + strings.Join(segments, "/")
 }
+
+// commandHyphenationAliases pairs command objects whose hyphenation differs
+// across firmware revisions. Only the command object (the token after the
+// verb) is swapped; parameters are left untouched.
+var commandHyphenationAliases = map[string]string{
+	"host-group":         "hostgroup",
+	"hostgroup":          "host-group",
+	"host-groups":        "hostgroups",
+	"hostgroups":         "host-groups",
+	"host-group-members": "hostgroup-members",
+	"hostgroup-members":  "host-group-members",
+	"initiator-nickname": "initiatornickname",
+	"initiatornickname":  "initiator-nickname",
+}
+
+// alternateHyphenation returns the command with its object swapped for the
+// alternate hyphenation, or false when the command has no known alias.
+func alternateHyphenation(parts []string) ([]string, bool) {
+	tokens := make([]string, 0, len(parts))
+	for _, part := range parts {
+		tokens = append(tokens, strings.Fields(part)...)
+	}
+	if len(tokens) < 2 {
+		return nil, false
+	}
+	alias, ok := commandHyphenationAliases[strings.ToLower(tokens[1])]
+	if !ok {
+		return nil, false
+	}
+	tokens[1] = alias
+	return tokens, true
+}
+
+var unknownCommandMarkers = []string{
+	"invalid command",
+	"unknown command",
+	"unrecognized command",
+	"command not recognized",
+	"command not found",
+}
+
+func isUnknownCommandError(err error) bool {
+	var apiErr APIError
+	if !errors.As(err, &apiErr) || apiErr.PermissionDenied {
+		return false
+	}
+	msg := strings.ToLower(strings.TrimSpace(apiErr.Status.Response))
+	for _, marker := range unknownCommandMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
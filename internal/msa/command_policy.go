@@ -0,0 +1,103 @@
+package msa
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrCommandNotAllowed is returned by Execute for commands rejected by the
+// configured allow or deny list.
+var ErrCommandNotAllowed = errors.New("command not allowed by provider policy")
+
+// commandPattern matches a command by verb and, optionally, its object. Both
+// are compared case-insensitively with hyphens and a plural "s" ignored, so
+// "delete host" matches `delete hosts` and "* host-group" matches any verb on
+// host groups. Member objects count as their parent: "* host-group" also
+// matches `add host-group-members`, and "* host" matches `remove
+// host-members`.
+type commandPattern struct {
+	raw    string
+	verb   string
+	object string
+}
+
+func parseCommandPatterns(patterns []string) ([]commandPattern, error) {
+	parsed := make([]commandPattern, 0, len(patterns))
+	for _, raw := range patterns {
+		tokens := strings.Fields(raw)
+		if len(tokens) == 0 || len(tokens) > 2 {
+			return nil, fmt.Errorf("invalid command pattern %q: use a verb (e.g. \"delete\") or a verb and object (e.g. \"delete host\")", raw)
+		}
+		pattern := commandPattern{raw: strings.Join(tokens, " "), verb: normalizeCommandToken(tokens[0])}
+		if len(tokens) == 2 {
+			pattern.object = normalizeCommandObject(tokens[1])
+		}
+		parsed = append(parsed, pattern)
+	}
+	return parsed, nil
+}
+
+func (p commandPattern) matches(verb, object string) bool {
+	if p.verb != "*" && p.verb != verb {
+		return false
+	}
+	return p.object == "" || p.object == "*" || p.object == object
+}
+
+func normalizeCommandToken(token string) string {
+	token = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(token), "-", ""))
+	if len(token) > 1 && strings.HasSuffix(token, "s") && !strings.HasSuffix(token, "ss") {
+		token = strings.TrimSuffix(token, "s")
+	}
+	return token
+}
+
+// normalizeCommandObject normalizes an object like normalizeCommandToken and
+// folds member objects (host-group-members, host-members) onto the object
+// they change.
+func normalizeCommandObject(token string) string {
+	object := normalizeCommandToken(token)
+	if parent := strings.TrimSuffix(object, "member"); parent != object && parent != "" {
+		return parent
+	}
+	return object
+}
+
+// checkCommandPolicy rejects commands matching a deny pattern, or matching no
+// allow pattern when an allow list is set. show commands are always allowed
+// so reads and drift detection keep working.
+func checkCommandPolicy(allow, deny []commandPattern, parts []string) error {
+	if len(allow) == 0 && len(deny) == 0 {
+		return nil
+	}
+	tokens := make([]string, 0, len(parts))
+	for _, part := range parts {
+		tokens = append(tokens, strings.Fields(part)...)
+	}
+	if len(tokens) == 0 || strings.EqualFold(tokens[0], "show") {
+		return nil
+	}
+
+	verb := normalizeCommandToken(tokens[0])
+	object := ""
+	if len(tokens) > 1 {
+		object = normalizeCommandObject(tokens[1])
+	}
+	command := strings.Join(RedactCommand(tokens[:min(len(tokens), 2)]), " ")
+
+	for _, pattern := range deny {
+		if pattern.matches(verb, object) {
+			return fmt.Errorf("%w: %q is denied by %q", ErrCommandNotAllowed, command, pattern.raw)
+		}
+	}
+	if len(allow) == 0 {
+		return nil
+	}
+	for _, pattern := range allow {
+		if pattern.matches(verb, object) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q is not in the allowed commands", ErrCommandNotAllowed, command)
+}
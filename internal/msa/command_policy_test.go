@@ -0,0 +1,154 @@
+package msa
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckCommandPolicyAllowList(t *testing.T) {
+	allow, err := parseCommandPatterns([]string{"create volume", "delete volume", "* snapshot", "map"})
+	if err != nil {
+		t.Fatalf("parse patterns: %v", err)
+	}
+
+	allowed := [][]string{
+		{"create", "volume", "vol01", "pool", "A", "size", "10GB"},
+		{"delete", "volumes", "vol01"},
+		{"create", "snapshots", "volumes", "vol01", "snap01"},
+		{"delete", "snapshot", "snap01"},
+		{"map", "volume", "vol01", "initiator", "host-a.*"},
+		{"show", "hosts"},
+	}
+	for _, parts := range allowed {
+		if err := checkCommandPolicy(allow, nil, parts); err != nil {
+			t.Fatalf("expected %v to be allowed, got %v", parts, err)
+		}
+	}
+
+	blocked := [][]string{
+		{"delete", "hosts", "host-a"},
+		{"create", "host", "host-a"},
+		{"set", "volume", "vol01", "name", "vol02"},
+		{"unmap", "volume", "vol01"},
+	}
+	for _, parts := range blocked {
+		err := checkCommandPolicy(allow, nil, parts)
+		if !errors.Is(err, ErrCommandNotAllowed) {
+			t.Fatalf("expected %v to be rejected, got %v", parts, err)
+		}
+		if !strings.Contains(err.Error(), "not in the allowed commands") {
+			t.Fatalf("unexpected message: %v", err)
+		}
+	}
+}
+
+func TestCheckCommandPolicyDenyList(t *testing.T) {
+	deny, err := parseCommandPatterns([]string{"delete host", "* host-group", "unmap"})
+	if err != nil {
+		t.Fatalf("parse patterns: %v", err)
+	}
+
+	denied := []struct {
+		parts   []string
+		pattern string
+	}{
+		{parts: []string{"delete", "hosts", "host-a"}, pattern: "delete host"},
+		{parts: []string{"create", "hostgroup", "hosts", "host-a", "cluster"}, pattern: "* host-group"},
+		{parts: []string{"delete", "host-groups", "cluster"}, pattern: "* host-group"},
+		{parts: []string{"add", "host-group-members", "hosts", "host-b", "cluster"}, pattern: "* host-group"},
+		{parts: []string{"remove", "host-group-members", "hosts", "host-b", "cluster"}, pattern: "* host-group"},
+		{parts: []string{"unmap", "volume", "vol01"}, pattern: "unmap"},
+	}
+	for _, tc := range denied {
+		err := checkCommandPolicy(nil, deny, tc.parts)
+		if !errors.Is(err, ErrCommandNotAllowed) {
+			t.Fatalf("expected %v to be denied, got %v", tc.parts, err)
+		}
+		if !strings.Contains(err.Error(), "denied by \""+tc.pattern+"\"") {
+			t.Fatalf("expected %v to name pattern %q, got %v", tc.parts, tc.pattern, err)
+		}
+	}
+
+	permitted := [][]string{
+		{"delete", "volumes", "vol01"},
+		{"create", "host", "host-a"},
+		{"set", "host", "host-a", "profile", "standard"},
+		{"add", "host-members", "initiators", "host-a-port1", "host-a"},
+		{"delete", "initiator-nickname", "host-a-port0"},
+		{"show", "host-groups"},
+		{"map", "volume", "vol01", "initiator", "host-a.*"},
+	}
+	for _, parts := range permitted {
+		if err := checkCommandPolicy(nil, deny, parts); err != nil {
+			t.Fatalf("expected %v to be permitted, got %v", parts, err)
+		}
+	}
+
+	allow, _ := parseCommandPatterns([]string{"delete"})
+	if err := checkCommandPolicy(allow, deny, []string{"delete", "hosts", "host-a"}); !errors.Is(err, ErrCommandNotAllowed) {
+		t.Fatalf("expected the deny list to win over the allow list, got %v", err)
+	}
+}
+
+func TestParseCommandPatternsRejectsInvalidEntries(t *testing.T) {
+	for _, pattern := range []string{"", "  ", "delete volume vol01"} {
+		if _, err := parseCommandPatterns([]string{pattern}); err == nil {
+			t.Fatalf("expected %q to be rejected", pattern)
+		}
+	}
+}
+
+func TestExecuteEnforcesCommandPolicy(t *testing.T) {
+	commandOK := readFixture(t, "command_success.xml")
+	paths := make([]string, 0)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		if strings.HasPrefix(r.URL.Path, "/api/login/") {
+			_, _ = w.Write(loginResponse("session-1"))
+			return
+		}
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write(commandOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{
+		Endpoint:        server.URL,
+		Username:        "user",
+		Password:        "pass",
+		InsecureTLS:     true,
+		AllowedCommands: []string{"create volume", "delete volume"},
+		DeniedCommands:  []string{"delete host"},
+		ForceEnglish:    true,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	client.retryConfig = RetryConfig{MaxAttempts: 1}
+
+	ctx := context.Background()
+	if _, err := client.Execute(ctx, "delete", "hosts", "host-a"); !errors.Is(err, ErrCommandNotAllowed) {
+		t.Fatalf("expected delete hosts to be denied, got %v", err)
+	}
+	if _, err := client.Execute(ctx, "create", "snapshots", "volumes", "vol01", "snap01"); !errors.Is(err, ErrCommandNotAllowed) {
+		t.Fatalf("expected create snapshots to be outside the allow list, got %v", err)
+	}
+	if _, err := client.Execute(ctx, "delete", "volumes", "vol01"); err != nil {
+		t.Fatalf("expected delete volumes to be allowed, got %v", err)
+	}
+	if _, err := client.Execute(ctx, "show", "hosts"); err != nil {
+		t.Fatalf("expected show to be allowed, got %v", err)
+	}
+
+	if strings.Join(paths, "|") != "/api/delete/volumes/vol01|/api/show/hosts" {
+		t.Fatalf("expected only permitted commands to reach the array, got %v", paths)
+	}
+
+	if _, err := NewClient(Config{Endpoint: server.URL, Username: "user", Password: "pass", DeniedCommands: []string{"delete volume vol01"}}); err == nil {
+		t.Fatalf("expected an invalid pattern to fail client creation")
+	}
+}
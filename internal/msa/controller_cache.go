@@ -0,0 +1,118 @@
+package msa
+
+import (
+	"sort"
+	"strings"
+)
+
+// ControllerCache is the cache state of one controller, merged from `show
+// controllers` (memory size and the nested compact-flash object) and `show
+// cache-parameters` (write-back and cache backup state).
+type ControllerCache struct {
+	ControllerID       string
+	CacheMemorySize    string
+	WriteBackStatus    string
+	CacheBackupStatus  string
+	CompactFlashStatus string
+	CompactFlashHealth string
+}
+
+// ControllerCachesFromResponses merges the controller, compact-flash and
+// cache-parameters objects of the given responses by controller ID, sorted
+// by ID. Objects without a controller ID are ignored.
+func ControllerCachesFromResponses(responses ...Response) []ControllerCache {
+	byID := make(map[string]*ControllerCache)
+	for _, response := range responses {
+		for _, obj := range response.ObjectsWithoutStatus() {
+			kind := controllerCacheObjectKind(obj)
+			if kind == "" {
+				continue
+			}
+			props := obj.PropertyMap()
+			id := controllerCacheID(props)
+			if id == "" {
+				continue
+			}
+			cache, ok := byID[id]
+			if !ok {
+				cache = &ControllerCache{ControllerID: id}
+				byID[id] = cache
+			}
+			applyControllerCacheProperties(cache, kind, props)
+		}
+	}
+
+	caches := make([]ControllerCache, 0, len(byID))
+	for _, cache := range byID {
+		caches = append(caches, *cache)
+	}
+	sort.Slice(caches, func(i, j int) bool {
+		return caches[i].ControllerID < caches[j].ControllerID
+	})
+	return caches
+}
+
+// BackupDegraded reports whether the cache backup unit (supercapacitor or
+// compact flash) is reported in a state other than OK. The array falls back
+// to write-through caching while it is degraded.
+func (c ControllerCache) BackupDegraded() bool {
+	return !isHealthyCacheStatus(c.CacheBackupStatus) || !isHealthyCacheStatus(c.CompactFlashHealth)
+}
+
+func controllerCacheObjectKind(obj Object) string {
+	switch strings.ToLower(obj.BaseType) {
+	case "controllers", "controller":
+		return "controller"
+	case "compact-flash":
+		return "compact-flash"
+	case "controller-cache-parameters", "cache-parameter", "cache-parameters":
+		return "cache-parameters"
+	default:
+		return ""
+	}
+}
+
+// controllerCacheID returns the controller letter ("A", "B"), taken from
+// controller-id or the suffix of durable-id (e.g. controller_a, cf_b).
+func controllerCacheID(props map[string]string) string {
+	if id := strings.TrimSpace(props["controller-id"]); id != "" {
+		return strings.ToUpper(id)
+	}
+	durableID := strings.TrimSpace(props["durable-id"])
+	if index := strings.LastIndexAny(durableID, "_-"); index >= 0 && index < len(durableID)-1 {
+		return strings.ToUpper(durableID[index+1:])
+	}
+	return ""
+}
+
+func applyControllerCacheProperties(cache *ControllerCache, kind string, props map[string]string) {
+	switch kind {
+	case "controller":
+		setIfEmpty(&cache.CacheMemorySize, props["cache-memory-size"])
+	case "compact-flash":
+		setIfEmpty(&cache.CompactFlashStatus, props["status"])
+		setIfEmpty(&cache.CompactFlashHealth, props["health"])
+	case "cache-parameters":
+		setIfEmpty(&cache.WriteBackStatus, props["write-back-status"])
+		setIfEmpty(&cache.CacheBackupStatus, firstNonEmpty(props["cache-backup-status"], props["supercap-health"], props["supercap-status"]))
+		setIfEmpty(&cache.CompactFlashStatus, props["compact-flash-status"])
+		setIfEmpty(&cache.CompactFlashHealth, props["compact-flash-health"])
+	}
+}
+
+func setIfEmpty(field *string, value string) {
+	if *field == "" {
+		*field = strings.TrimSpace(value)
+	}
+}
+
+// isHealthyCacheStatus treats an unreported status as healthy so firmware
+// without the property does not raise false warnings.
+func isHealthyCacheStatus(status string) bool {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "", "ok", "good", "ready", "charged", "fully charged", "n/a":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,53 @@
+package msa
+
+import "testing"
+
+func TestControllerCachesFromResponses(t *testing.T) {
+	caches := ControllerCachesFromResponses(
+		mustParseFixture(t, "show_controllers.xml"),
+		mustParseFixture(t, "show_cache_parameters.xml"),
+	)
+	if len(caches) != 2 {
+		t.Fatalf("expected 2 controllers, got %+v", caches)
+	}
+
+	a := caches[0]
+	if a.ControllerID != "A" || a.CacheMemorySize != "6144" || a.WriteBackStatus != "Enabled" {
+		t.Fatalf("unexpected controller A cache %+v", a)
+	}
+	if a.CacheBackupStatus != "OK" || a.CompactFlashStatus != "Installed" || a.CompactFlashHealth != "OK" {
+		t.Fatalf("unexpected controller A backup state %+v", a)
+	}
+	if a.BackupDegraded() {
+		t.Fatalf("expected controller A backup to be healthy")
+	}
+
+	b := caches[1]
+	if b.ControllerID != "B" || b.WriteBackStatus != "Disabled" || b.CacheBackupStatus != "Charging" || b.CompactFlashHealth != "Degraded" {
+		t.Fatalf("unexpected controller B cache %+v", b)
+	}
+	if !b.BackupDegraded() {
+		t.Fatalf("expected controller B backup to be degraded")
+	}
+}
+
+func TestControllerCachesFromControllersOnly(t *testing.T) {
+	caches := ControllerCachesFromResponses(mustParseFixture(t, "show_controllers.xml"))
+	if len(caches) != 2 {
+		t.Fatalf("expected 2 controllers, got %+v", caches)
+	}
+	if caches[0].WriteBackStatus != "" || caches[0].CacheBackupStatus != "" {
+		t.Fatalf("expected no cache parameters without show cache-parameters, got %+v", caches[0])
+	}
+	if caches[0].BackupDegraded() || !caches[1].BackupDegraded() {
+		t.Fatalf("expected degradation from the compact-flash health alone, got %+v", caches)
+	}
+
+	byDurableID := ControllerCachesFromResponses(Response{Objects: []Object{{
+		BaseType:   "controllers",
+		Properties: []Property{{Name: "durable-id", Value: "controller_b"}, {Name: "cache-memory-size", Value: "8192"}},
+	}}})
+	if len(byDurableID) != 1 || byDurableID[0].ControllerID != "B" {
+		t.Fatalf("expected the controller ID from durable-id, got %+v", byDurableID)
+	}
+}
@@ -0,0 +1,114 @@
+package msa
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ControllerDate is the array clock as reported by `show controller-date`.
+type ControllerDate struct {
+	Time           time.Time
+	TimeZoneOffset string
+	NTPState       string
+	NTPServer      string
+	Properties     map[string]string
+}
+
+var errControllerDateMissing = errors.New("response does not contain a controller date")
+
+var controllerDateLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"Mon Jan _2 15:04:05 2006",
+	"Mon Jan _2 15:04:05 MST 2006",
+}
+
+// ControllerDateFromResponse reads the array time from `show controller-date`
+// (or any response carrying a date-time property, such as some `show system`
+// variants). The local date-time plus time-zone-offset is preferred; the
+// numeric epoch value is the fallback.
+func ControllerDateFromResponse(response Response) (ControllerDate, error) {
+	for _, obj := range response.ObjectsWithoutStatus() {
+		props := obj.PropertyMap()
+		raw := strings.TrimSpace(firstNonEmpty(props["date-time"], props["controller-date"]))
+		numeric := strings.TrimSpace(props["date-time-numeric"])
+		if raw == "" && numeric == "" {
+			continue
+		}
+
+		date := ControllerDate{
+			TimeZoneOffset: strings.TrimSpace(props["time-zone-offset"]),
+			NTPState:       strings.TrimSpace(props["ntp-state"]),
+			NTPServer:      strings.TrimSpace(props["ntp-server-address"]),
+			Properties:     props,
+		}
+		parsed, err := parseControllerTime(raw, date.TimeZoneOffset)
+		if err != nil {
+			seconds, ok := parseUint(numeric)
+			if !ok {
+				if raw == "" {
+					return ControllerDate{}, fmt.Errorf("invalid date-time-numeric %q", numeric)
+				}
+				return ControllerDate{}, err
+			}
+			parsed = time.Unix(seconds, 0).UTC()
+		}
+		date.Time = parsed
+		return date, nil
+	}
+	return ControllerDate{}, errControllerDateMissing
+}
+
+func parseControllerTime(raw, offset string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, errors.New("empty date-time")
+	}
+	location, err := parseTimeZoneOffset(offset)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, layout := range controllerDateLayouts {
+		if parsed, err := time.ParseInLocation(layout, raw, location); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date-time %q", raw)
+}
+
+// parseTimeZoneOffset accepts "+01:00", "-0530", or "" (UTC).
+func parseTimeZoneOffset(offset string) (*time.Location, error) {
+	offset = strings.TrimSpace(offset)
+	if offset == "" {
+		return time.UTC, nil
+	}
+	name := "UTC" + offset
+	sign := 1
+	switch offset[0] {
+	case '+':
+		offset = offset[1:]
+	case '-':
+		sign = -1
+		offset = offset[1:]
+	}
+	hours, minutes := offset, "0"
+	if i := strings.Index(offset, ":"); i >= 0 {
+		hours, minutes = offset[:i], offset[i+1:]
+	} else if len(offset) == 4 {
+		hours, minutes = offset[:2], offset[2:]
+	}
+	h, errH := strconv.Atoi(hours)
+	m, errM := strconv.Atoi(minutes)
+	if errH != nil || errM != nil || h > 14 || m > 59 {
+		return nil, fmt.Errorf("invalid time-zone-offset %q", strings.TrimPrefix(name, "UTC"))
+	}
+	return time.FixedZone(name, sign*(h*3600+m*60)), nil
+}
+
+// ClockSkew returns how far the array clock is ahead of reference (negative
+// when it is behind).
+func (d ControllerDate) ClockSkew(reference time.Time) time.Duration {
+	return d.Time.Sub(reference)
+}
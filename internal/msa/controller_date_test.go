@@ -0,0 +1,68 @@
+package msa
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestControllerDateFromResponse(t *testing.T) {
+	date, err := ControllerDateFromResponse(mustParseFixture(t, "show_controller_date.xml"))
+	if err != nil {
+		t.Fatalf("parse controller date: %v", err)
+	}
+	want := time.Date(2026, 3, 15, 9, 22, 33, 0, time.UTC)
+	if !date.Time.Equal(want) {
+		t.Fatalf("expected %s, got %s", want, date.Time)
+	}
+	if date.TimeZoneOffset != "+01:00" || date.NTPState != "Disabled" || date.NTPServer != "0.0.0.0" {
+		t.Fatalf("unexpected settings %+v", date)
+	}
+
+	if skew := date.ClockSkew(want.Add(-90 * time.Second)); skew != 90*time.Second {
+		t.Fatalf("expected array 90s ahead, got %s", skew)
+	}
+	if skew := date.ClockSkew(want.Add(30 * time.Second)); skew != -30*time.Second {
+		t.Fatalf("expected array 30s behind, got %s", skew)
+	}
+}
+
+func TestControllerDateFallsBackToNumeric(t *testing.T) {
+	response := Response{Objects: []Object{{
+		BaseType: "time-settings-table",
+		Properties: []Property{
+			{Name: "date-time", Value: "garbled"},
+			{Name: "date-time-numeric", Value: "1773566553"},
+		},
+	}}}
+	date, err := ControllerDateFromResponse(response)
+	if err != nil {
+		t.Fatalf("parse controller date: %v", err)
+	}
+	if !date.Time.Equal(time.Date(2026, 3, 15, 9, 22, 33, 0, time.UTC)) {
+		t.Fatalf("unexpected time %s", date.Time)
+	}
+}
+
+func TestControllerDateMissing(t *testing.T) {
+	_, err := ControllerDateFromResponse(mustParseFixture(t, "command_success.xml"))
+	if !errors.Is(err, errControllerDateMissing) {
+		t.Fatalf("expected missing date error, got %v", err)
+	}
+}
+
+func TestParseTimeZoneOffset(t *testing.T) {
+	cases := map[string]int{"": 0, "+01:00": 3600, "-05:30": -19800, "+0930": 34200}
+	for input, want := range cases {
+		location, err := parseTimeZoneOffset(input)
+		if err != nil {
+			t.Fatalf("%q: %v", input, err)
+		}
+		if _, offset := time.Date(2026, 1, 1, 0, 0, 0, 0, location).Zone(); offset != want {
+			t.Fatalf("%q: expected offset %d, got %d", input, want, offset)
+		}
+	}
+	if _, err := parseTimeZoneOffset("+25:00"); err == nil {
+		t.Fatalf("expected invalid offset to be rejected")
+	}
+}
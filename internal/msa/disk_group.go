@@ -0,0 +1,29 @@
+package msa
+
+import (
+	"strings"
+)
+
+type DiskGroup struct {
+	Name         string
+	SerialNumber string
+	Status       string
+	Properties   map[string]string
+}
+
+func DiskGroupsFromResponse(response Response) []DiskGroup {
+	groups := make([]DiskGroup, 0)
+	for _, obj := range response.ObjectsWithoutStatus() {
+		if obj.BaseType != "disk-groups" && obj.BaseType != "disk-group" {
+			continue
+		}
+		props := obj.PropertyMap()
+		groups = append(groups, DiskGroup{
+			Name:         strings.TrimSpace(props["name"]),
+			SerialNumber: strings.TrimSpace(props["serial-number"]),
+			Status:       strings.TrimSpace(props["status"]),
+			Properties:   props,
+		})
+	}
+	return groups
+}
@@ -0,0 +1,18 @@
+package msa
+
+import "testing"
+
+func TestDiskGroupsFromResponse(t *testing.T) {
+	groups := DiskGroupsFromResponse(mustParseFixture(t, "show_disk_groups.xml"))
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 disk groups, got %d", len(groups))
+	}
+
+	first := groups[0]
+	if first.Name != "dgA01" || first.SerialNumber != "00c0ff3cab9c00003c1e5e5c00000000" || first.Status != "FTOL" {
+		t.Fatalf("unexpected disk group %+v", first)
+	}
+	if groups[2].Name != "dgB02" {
+		t.Fatalf("unexpected disk group %+v", groups[2])
+	}
+}
@@ -3,27 +3,226 @@ package msa
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 type APIError struct {
 	Status Status
+	// PermissionDenied is set when the array rejected the command because the
+	// configured user lacks the required role. Such errors are never retried.
+	PermissionDenied bool
+}
+
+func newAPIError(status Status) APIError {
+	return APIError{
+		Status:           status,
+		PermissionDenied: isPermissionDeniedStatus(status),
+	}
 }
 
 func (e APIError) Error() string {
 	response := strings.TrimSpace(e.Status.Response)
+	if e.PermissionDenied {
+		if response == "" {
+			return "permission denied: the configured user lacks the 'manage' role for this operation"
+		}
+		return fmt.Sprintf("permission denied: the configured user lacks the 'manage' role for this operation: %s", response)
+	}
 	if response == "" {
 		return "command failed"
 	}
 	return fmt.Sprintf("command failed: %s", response)
 }
 
+// parseErrorSnippetSize caps the body excerpt carried by ParseError.
+const parseErrorSnippetSize = 512
+
+// ParseError is returned when a response body cannot be decoded. Snippet is
+// the start of the body, truncated and with credentials and session keys
+// redacted, so firmware quirks can be diagnosed from the error alone.
+type ParseError struct {
+	Err     error
+	Size    int
+	Snippet string
+
+	// body is the raw response, kept until the client has redacted its own
+	// secrets so that redaction never runs on an already truncated snippet.
+	body string
+}
+
+func newParseError(err error, body []byte) ParseError {
+	raw := strings.ToValidUTF8(string(body), "?")
+	return ParseError{Err: err, Size: len(body), Snippet: responseSnippet(raw), body: raw}
+}
+
+func (e ParseError) Error() string {
+	if e.Snippet == "" {
+		return fmt.Sprintf("%v (empty response body)", e.Err)
+	}
+	return fmt.Sprintf("%v (%d-byte body starts with %q)", e.Err, e.Size, e.Snippet)
+}
+
+func (e ParseError) Unwrap() error {
+	return e.Err
+}
+
+var sensitivePropertyPattern = regexp.MustCompile(`(?is)(<PROPERTY[^>]*\bname="[^"]*(?:password|secret|community|passphrase|session)[^"]*"[^>]*>)[^<]*`)
+
+// responseSnippet redacts the values of password, secret and session
+// properties in the raw body, then collapses whitespace and keeps the first
+// parseErrorSnippetSize bytes. Redacting first means a secret is never
+// half-cut by the truncation or split by the whitespace collapse.
+func responseSnippet(body string) string {
+	text := sensitivePropertyPattern.ReplaceAllString(body, "${1}"+auditRedacted)
+	text = loginHashPattern.ReplaceAllString(text, "/api/login/"+auditRedacted)
+	text = strings.Join(strings.Fields(text), " ")
+	return truncateSnippet(text)
+}
+
+func truncateSnippet(text string) string {
+	if len(text) <= parseErrorSnippetSize {
+		return text
+	}
+	cut := parseErrorSnippetSize
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + "..."
+}
+
+// PartialFailureError is returned when a bulk command reports one status
+// per item and only some of them failed. Unwrap yields the first failure as
+// an APIError so existing classifiers keep working.
+type PartialFailureError struct {
+	Statuses []Status
+}
+
+// Failed returns the statuses that did not succeed, in response order.
+func (e PartialFailureError) Failed() []Status {
+	failed := make([]Status, 0, len(e.Statuses))
+	for _, status := range e.Statuses {
+		if !status.Success() {
+			failed = append(failed, status)
+		}
+	}
+	return failed
+}
+
+// Succeeded returns the statuses that succeeded, in response order.
+func (e PartialFailureError) Succeeded() []Status {
+	succeeded := make([]Status, 0, len(e.Statuses))
+	for _, status := range e.Statuses {
+		if status.Success() {
+			succeeded = append(succeeded, status)
+		}
+	}
+	return succeeded
+}
+
+func (e PartialFailureError) Error() string {
+	failed := e.Failed()
+	messages := make([]string, 0, len(failed))
+	for _, status := range failed {
+		if response := strings.TrimSpace(status.Response); response != "" {
+			messages = append(messages, response)
+		}
+	}
+	return fmt.Sprintf("command partially failed (%d of %d statuses reported errors): %s", len(failed), len(e.Statuses), strings.Join(messages, "; "))
+}
+
+func (e PartialFailureError) Unwrap() error {
+	failed := e.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return newAPIError(failed[0])
+}
+
+// statusesError aggregates the statuses of one response: nil when all
+// succeeded, an APIError when none did, and a PartialFailureError otherwise.
+func statusesError(statuses []Status) error {
+	failed := 0
+	var first Status
+	for _, status := range statuses {
+		if !status.Success() {
+			if failed == 0 {
+				first = status
+			}
+			failed++
+		}
+	}
+	switch {
+	case failed == 0:
+		return nil
+	case failed == len(statuses):
+		return newAPIError(first)
+	default:
+		return PartialFailureError{Statuses: statuses}
+	}
+}
+
 func IsSessionError(err error) bool {
 	var apiErr APIError
 	if !errors.As(err, &apiErr) {
 		return false
 	}
+	if apiErr.PermissionDenied {
+		return false
+	}
 
 	msg := strings.ToLower(apiErr.Status.Response)
 	return strings.Contains(msg, "session") || strings.Contains(msg, "login") || strings.Contains(msg, "authorization")
 }
+
+// IsPermissionDenied reports whether err is an APIError caused by the
+// configured user lacking the role required for the command.
+func IsPermissionDenied(err error) bool {
+	var apiErr APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.PermissionDenied
+}
+
+var permissionDeniedMarkers = []string{
+	"permission denied",
+	"do not have permission",
+	"does not have permission",
+	"not have the required permission",
+	"insufficient privilege",
+	"insufficient permission",
+	"insufficient user role",
+	"not authorized to",
+	"access denied",
+	"access is denied",
+	"requires the manage role",
+	"user role does not allow",
+}
+
+// isPermissionDeniedStatus classifies a failed status by its return code
+// first. A specific code already identifies the failure, so its message is
+// never searched for permission wording; only the array's generic codes fall
+// back to the markers above, which could otherwise match messages such as a
+// mapping's "access" settings.
+func isPermissionDeniedStatus(status Status) bool {
+	if status.Success() || !isGenericReturnCode(status.ReturnCode) {
+		return false
+	}
+	msg := strings.ToLower(strings.TrimSpace(status.Response))
+	for _, marker := range permissionDeniedMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGenericReturnCode reports whether code says nothing about the cause of
+// a failure: -1 is the array's catch-all error code, and firmware that only
+// fills in response-type leaves 0 or 1. Specific failures use codes such as
+// -10016.
+func isGenericReturnCode(code int) bool {
+	return code >= -1 && code <= 1
+}
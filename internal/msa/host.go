@@ -12,7 +12,10 @@ type Host struct {
 	HostGroup    string
 	GroupKey     string
 	MemberCount  int
-	Properties   map[string]string
+	Profile      string
+	// Initiators are the member initiators nested under the host object.
+	Initiators []Initiator
+	Properties map[string]string
 }
 
 func HostsFromResponse(response Response) []Host {
@@ -46,6 +49,47 @@ func hostFromObject(obj Object) Host {
 		HostGroup:    props["host-group"],
 		GroupKey:     props["group-key"],
 		MemberCount:  memberCount,
+		Profile:      hostProfile(obj, props),
+		Initiators:   hostInitiators(obj, props),
 		Properties:   props,
 	}
 }
+
+// hostInitiators returns the initiators nested under a host, filling in the
+// host linkage from the parent when the firmware omits host-id/host-key on
+// the child rows.
+func hostInitiators(obj Object, props map[string]string) []Initiator {
+	initiators := make([]Initiator, 0)
+	for _, child := range obj.Objects {
+		if child.BaseType != "initiator" {
+			continue
+		}
+		initiator := initiatorFromObject(child)
+		if initiator.HostID == "" {
+			initiator.HostID = props["serial-number"]
+		}
+		if initiator.HostKey == "" {
+			initiator.HostKey = props["durable-id"]
+		}
+		initiators = append(initiators, initiator)
+	}
+	return initiators
+}
+
+// hostProfile prefers a host-level profile and otherwise falls back to the
+// profile of the first nested initiator, since `set host profile` applies the
+// value to every member initiator.
+func hostProfile(obj Object, props map[string]string) string {
+	if profile := strings.TrimSpace(props["profile"]); profile != "" {
+		return profile
+	}
+	for _, child := range obj.Objects {
+		if child.BaseType != "initiator" {
+			continue
+		}
+		if profile := strings.TrimSpace(child.PropertyMap()["profile"]); profile != "" {
+			return profile
+		}
+	}
+	return ""
+}
@@ -43,7 +43,14 @@ func hostGroupFromObject(obj Object) HostGroup {
 		if !isHostObject(child) {
 			continue
 		}
-		hosts = append(hosts, hostFromObject(child))
+		host := hostFromObject(child)
+		if host.HostGroup == "" {
+			host.HostGroup = props["serial-number"]
+		}
+		if host.GroupKey == "" {
+			host.GroupKey = props["durable-id"]
+		}
+		hosts = append(hosts, host)
 	}
 
 	return HostGroup{
@@ -1,6 +1,9 @@
 package msa
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func TestHostGroupsFromResponse(t *testing.T) {
 	fixture := readFixture(t, "show_host_groups.xml")
@@ -34,3 +37,45 @@ func TestHostGroupsFromResponse(t *testing.T) {
 		t.Fatalf("unexpected hosts: %v", group.Hosts)
 	}
 }
+
+func TestHostGroupsFromResponseReconstructsHierarchy(t *testing.T) {
+	response := mustParseFixture(t, "show_host_groups_hierarchy.xml")
+
+	type initiatorView struct{ ID, Nickname, HostID, HostKey string }
+	type hostView struct {
+		Name, HostGroup, GroupKey string
+		Initiators                []initiatorView
+	}
+	got := make(map[string][]hostView)
+	for _, group := range HostGroupsFromResponse(response) {
+		hosts := make([]hostView, 0, len(group.Hosts))
+		for _, host := range group.Hosts {
+			view := hostView{Name: host.Name, HostGroup: host.HostGroup, GroupKey: host.GroupKey}
+			for _, initiator := range host.Initiators {
+				view.Initiators = append(view.Initiators, initiatorView{initiator.ID, initiator.Nickname, initiator.HostID, initiator.HostKey})
+			}
+			hosts = append(hosts, view)
+		}
+		got[group.Name] = hosts
+	}
+
+	want := map[string][]hostView{
+		"esx-cluster": {
+			{Name: "esx-a", HostGroup: "00c0ff3cab9c0000000000000b010000", GroupKey: "HG1", Initiators: []initiatorView{
+				{"21000024ff3dfed0", "esx-a-fc0", "00c0ff3cab9c00000000000003010000", "H3"},
+				{"21000024ff3dfed1", "esx-a-fc1", "00c0ff3cab9c00000000000003010000", "H3"},
+			}},
+			{Name: "esx-b", HostGroup: "00c0ff3cab9c0000000000000b010000", GroupKey: "HG1", Initiators: []initiatorView{
+				{"21000024ff3dfee0", "esx-b-fc0", "00c0ff3cab9c00000000000004010000", "H4"},
+			}},
+		},
+		"UNGROUPED": {
+			{Name: "backup01", HostGroup: "UNGROUPEDHOSTS", GroupKey: "HG0", Initiators: []initiatorView{
+				{"iqn.1991-05.com.microsoft:backup01", "backup01-iscsi", "00c0ff3cab9c00000000000005010000", "H5"},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected hierarchy:\n got %+v\nwant %+v", got, want)
+	}
+}
@@ -30,3 +30,37 @@ func TestHostsFromResponse(t *testing.T) {
 		t.Fatalf("expected host group UNGROUPEDHOSTS, got %q", hosts[0].HostGroup)
 	}
 }
+
+func TestHostProfileFromNestedInitiators(t *testing.T) {
+	fixture := readFixture(t, "show_host_groups_nested.xml")
+	response, err := parseResponse(fixture)
+	if err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+
+	hosts := HostsFromResponse(response)
+	if len(hosts) != 2 {
+		t.Fatalf("expected 2 hosts, got %d", len(hosts))
+	}
+	if hosts[0].Profile != "Standard" {
+		t.Fatalf("expected Standard profile for pve1, got %q", hosts[0].Profile)
+	}
+	if hosts[1].Profile != "HP-UX" {
+		t.Fatalf("expected HP-UX profile for pve2, got %q", hosts[1].Profile)
+	}
+}
+
+func TestHostsFromResponseNestsInitiators(t *testing.T) {
+	response := mustParseFixture(t, "show_host_groups_nested.xml")
+
+	hosts := HostsFromResponse(response)
+	if len(hosts) != 2 {
+		t.Fatalf("expected 2 hosts, got %d", len(hosts))
+	}
+	if len(hosts[0].Initiators) != 2 || len(hosts[1].Initiators) != 1 {
+		t.Fatalf("expected 2 and 1 initiators, got %d and %d", len(hosts[0].Initiators), len(hosts[1].Initiators))
+	}
+	if hosts[0].Initiators[1].ID != "500605b00d1a2b31" || hosts[1].Initiators[0].Nickname != "pve2-sas0" {
+		t.Fatalf("unexpected initiators: %+v / %+v", hosts[0].Initiators, hosts[1].Initiators)
+	}
+}
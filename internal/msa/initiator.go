@@ -1,6 +1,10 @@
 package msa
 
-import "strings"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 type Initiator struct {
 	ID          string
@@ -48,3 +52,34 @@ func initiatorFromObject(obj Object) Initiator {
 		Properties:  props,
 	}
 }
+
+func (i Initiator) IsSAS() bool {
+	return strings.EqualFold(i.HostBusType, "SAS")
+}
+
+// ConnectedPorts decodes the host-port-bits-a/b bitmasks (bit 0 = port 1)
+// into controller port names such as "A1" and "B1". It returns nil when the
+// array does not report connectivity for the initiator.
+func (i Initiator) ConnectedPorts() []string {
+	ports := make([]string, 0)
+	for _, controller := range []string{"a", "b"} {
+		raw := strings.TrimSpace(i.Properties["host-port-bits-"+controller])
+		if raw == "" {
+			continue
+		}
+		bits, err := strconv.ParseUint(raw, 0, 32)
+		if err != nil {
+			continue
+		}
+		for port := 1; bits != 0; port++ {
+			if bits&1 == 1 {
+				ports = append(ports, fmt.Sprintf("%s%d", strings.ToUpper(controller), port))
+			}
+			bits >>= 1
+		}
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+	return ports
+}
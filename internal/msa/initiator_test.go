@@ -27,3 +27,43 @@ func TestInitiatorsFromResponse(t *testing.T) {
 		t.Fatalf("unexpected profile %q", initiators[0].Profile)
 	}
 }
+
+func TestInitiatorConnectedPorts(t *testing.T) {
+	fixture := readFixture(t, "show_initiators_sas_connectivity.xml")
+	response, err := parseResponse(fixture)
+	if err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+
+	initiators := InitiatorsFromResponse(response)
+	if len(initiators) != 4 {
+		t.Fatalf("expected 4 initiators, got %d", len(initiators))
+	}
+
+	tests := []struct {
+		index int
+		sas   bool
+		want  []string
+	}{
+		{index: 0, sas: true, want: []string{"A1", "B1"}},
+		{index: 1, sas: true, want: []string{"A2", "B2"}},
+		{index: 2, sas: true, want: nil},
+		{index: 3, sas: false, want: []string{"A1", "A2", "B1", "B2"}},
+	}
+
+	for _, tc := range tests {
+		initiator := initiators[tc.index]
+		if initiator.IsSAS() != tc.sas {
+			t.Fatalf("%s: expected IsSAS=%v", initiator.Nickname, tc.sas)
+		}
+		got := initiator.ConnectedPorts()
+		if len(got) != len(tc.want) {
+			t.Fatalf("%s: expected ports %v, got %v", initiator.Nickname, tc.want, got)
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Fatalf("%s: expected ports %v, got %v", initiator.Nickname, tc.want, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package msa
+
+import (
+	"sort"
+	"strings"
+)
+
+// InventoryItem is one enclosure, power supply, fan, controller or FRU
+// reported by `show enclosures` or `show frus`.
+type InventoryItem struct {
+	ID           string
+	Type         string
+	EnclosureID  string
+	Name         string
+	Status       string
+	Model        string
+	SerialNumber string
+	PartNumber   string
+	Properties   map[string]string
+}
+
+// InventoryFromResponse parses the objects of `show enclosures` (including
+// the nested power supplies, fans and controllers) and `show frus`, sorted by
+// type and ID. Objects of other basetypes are ignored.
+func InventoryFromResponse(response Response) []InventoryItem {
+	items := make([]InventoryItem, 0)
+	for _, obj := range response.ObjectsWithoutStatus() {
+		itemType := inventoryType(obj)
+		if itemType == "" {
+			continue
+		}
+		item := inventoryItemFromObject(obj, itemType)
+		if item.ID == "" {
+			continue
+		}
+		items = append(items, item)
+	}
+	SortInventory(items)
+	return items
+}
+
+// SortInventory orders items by type and then ID so merged results from
+// several commands are deterministic.
+func SortInventory(items []InventoryItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Type != items[j].Type {
+			return items[i].Type < items[j].Type
+		}
+		return items[i].ID < items[j].ID
+	})
+}
+
+func inventoryType(obj Object) string {
+	switch strings.ToLower(obj.BaseType) {
+	case "enclosures", "enclosure":
+		return "enclosure"
+	case "power-supplies", "power-supply":
+		return "power-supply"
+	case "fan", "fans", "fan-details":
+		return "fan"
+	case "controllers", "controller":
+		return "controller"
+	case "enclosure-fru", "fru", "frus":
+		return "fru"
+	default:
+		return ""
+	}
+}
+
+func inventoryItemFromObject(obj Object, itemType string) InventoryItem {
+	props := obj.PropertyMap()
+
+	item := InventoryItem{
+		Type:         itemType,
+		EnclosureID:  strings.TrimSpace(props["enclosure-id"]),
+		Name:         strings.TrimSpace(firstNonEmpty(props["name"], props["fru-shortname"], props["description"])),
+		Status:       strings.TrimSpace(firstNonEmpty(props["status"], props["fru-status"], props["health"])),
+		Model:        strings.TrimSpace(firstNonEmpty(props["model"], props["description"], props["fru-shortname"])),
+		SerialNumber: strings.TrimSpace(firstNonEmpty(props["serial-number"], props["midplane-serial-number"], props["configuration-serialnumber"])),
+		PartNumber:   strings.TrimSpace(firstNonEmpty(props["part-number"], props["midplane-part-number"])),
+		Properties:   props,
+	}
+
+	if itemType == "fru" {
+		// FRUs have no durable ID; the enclosure and slot identify them.
+		slot := strings.TrimSpace(firstNonEmpty(props["fru-location"], props["name"]))
+		if slot != "" {
+			item.ID = slot
+			if item.EnclosureID != "" {
+				item.ID = item.EnclosureID + "/" + slot
+			}
+		}
+		return item
+	}
+
+	item.ID = strings.TrimSpace(firstNonEmpty(props["durable-id"], props["controller-id"], props["enclosure-id"]))
+	return item
+}
@@ -0,0 +1,50 @@
+package msa
+
+import "testing"
+
+func TestInventoryFromEnclosures(t *testing.T) {
+	response := mustParseFixture(t, "show_enclosures.xml")
+
+	items := InventoryFromResponse(response)
+	var ids []string
+	for _, item := range items {
+		ids = append(ids, item.Type+":"+item.ID)
+	}
+	want := []string{"enclosure:enclosure_0", "fan:fan_0.0", "fan:fan_0.1", "power-supply:psu_0.0", "power-supply:psu_0.1"}
+	if len(ids) != len(want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, ids)
+		}
+	}
+
+	enclosure := items[0]
+	if enclosure.SerialNumber != "00C0FF3CAB9C" || enclosure.Model != "SPS-CHASSIS 2U12 LFF" || enclosure.Status != "OK" {
+		t.Fatalf("unexpected enclosure %+v", enclosure)
+	}
+	psu := items[3]
+	if psu.Name != "PSU 1, Left" || psu.SerialNumber != "7CE817T117" || psu.PartNumber != "814665-001" || psu.EnclosureID != "0" {
+		t.Fatalf("unexpected power supply %+v", psu)
+	}
+}
+
+func TestInventoryFromFRUs(t *testing.T) {
+	response := mustParseFixture(t, "show_frus.xml")
+
+	items := InventoryFromResponse(response)
+	if len(items) != 2 {
+		t.Fatalf("expected 2 FRUs, got %d", len(items))
+	}
+	midplane := items[0]
+	if midplane.ID != "0/MID-PLANE SLOT" || midplane.Type != "fru" {
+		t.Fatalf("expected FRUs sorted by enclosure slot, got %+v", midplane)
+	}
+	if midplane.Status != "OK" || midplane.SerialNumber != "7CE817R044" || midplane.Model != "SPS-CHASSIS 2U12 LFF" {
+		t.Fatalf("unexpected midplane FRU %+v", midplane)
+	}
+	if items[1].ID != "0/UPPER IOM SLOT" || items[1].Name != "RAID_IOM" {
+		t.Fatalf("unexpected IOM FRU %+v", items[1])
+	}
+}
@@ -1,14 +1,26 @@
 package msa
 
-import "strings"
+import (
+	"sort"
+	"strings"
+)
 
 type Mapping struct {
 	Volume       string
 	VolumeSerial string
-	LUN          string
-	Access       string
-	Ports        string
-	Properties   map[string]string
+	// Target is the mapped-id reported by the volume-keyed view (host,
+	// host group or initiator). It is empty for initiator-keyed rows.
+	Target     string
+	LUN        string
+	Access     string
+	Ports      string
+	PortLUNs   map[string]string
+	Properties map[string]string
+}
+
+// PortList splits the reported ports ("A1,B1" or "A1 B1") into their names.
+func (m Mapping) PortList() []string {
+	return splitList(m.Ports)
 }
 
 func MappingsFromResponse(response Response) []Mapping {
@@ -31,8 +43,151 @@ func MappingsFromResponse(response Response) []Mapping {
 			LUN:          props["lun"],
 			Access:       props["access"],
 			Ports:        props["ports"],
+			PortLUNs:     portLUNs(props["ports"], lun),
 			Properties:   props,
 		})
 	}
-	return mappings
+	return DedupeMappings(mappings)
+}
+
+// MappingsByVolumeFromResponse parses the volume-keyed view returned by
+// `show maps volume <volume>`. The parent volume-view object carries the
+// volume name and serial; each volume-view-mappings child describes one
+// target and is returned with Target set to its mapped-id.
+func MappingsByVolumeFromResponse(response Response) []Mapping {
+	mappings := make([]Mapping, 0)
+	var walk func(objects []Object, volume, serial string)
+	walk = func(objects []Object, volume, serial string) {
+		for _, obj := range objects {
+			if obj.BaseType == "status" || obj.Name == "status" {
+				continue
+			}
+			props := obj.PropertyMap()
+			target := strings.TrimSpace(firstNonEmpty(props["mapped-id"], props["identifier"]))
+			if target == "" {
+				walk(obj.Objects, firstNonEmpty(props["volume-name"], props["volume"], volume), firstNonEmpty(props["volume-serial"], props["serial-number"], serial))
+				continue
+			}
+
+			name := firstNonEmpty(props["volume-name"], props["volume"], volume)
+			access := strings.ToLower(strings.TrimSpace(props["access"]))
+			lun := strings.TrimSpace(props["lun"])
+			if name == "" || (lun == "" && access != "no-access") {
+				continue
+			}
+			mappings = append(mappings, Mapping{
+				Volume:       name,
+				VolumeSerial: firstNonEmpty(props["volume-serial"], serial),
+				Target:       target,
+				LUN:          props["lun"],
+				Access:       props["access"],
+				Ports:        props["ports"],
+				PortLUNs:     portLUNs(props["ports"], lun),
+				Properties:   props,
+			})
+		}
+	}
+	walk(response.Objects, "", "")
+	return DedupeMappings(mappings)
+}
+
+// DedupeMappings collapses rows describing the same logical mapping (volume,
+// target and LUN). Some firmware lists a mapping once per controller; the
+// ports and per-port LUNs of the duplicates are merged into the first row.
+func DedupeMappings(mappings []Mapping) []Mapping {
+	result := make([]Mapping, 0, len(mappings))
+	index := make(map[string]int)
+	for _, mapping := range mappings {
+		key := mappingKey(mapping)
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(result)
+			result = append(result, mapping)
+			continue
+		}
+		merged := &result[i]
+		merged.Ports = mergePortLists(merged.Ports, mapping.Ports)
+		if len(mapping.PortLUNs) > 0 {
+			portLUNs := make(map[string]string, len(merged.PortLUNs)+len(mapping.PortLUNs))
+			for port, lun := range merged.PortLUNs {
+				portLUNs[port] = lun
+			}
+			for port, lun := range mapping.PortLUNs {
+				portLUNs[port] = lun
+			}
+			merged.PortLUNs = portLUNs
+		}
+		merged.VolumeSerial = firstNonEmpty(merged.VolumeSerial, mapping.VolumeSerial)
+	}
+	return result
+}
+
+func mappingKey(mapping Mapping) string {
+	volume := firstNonEmpty(mapping.Volume, mapping.VolumeSerial)
+	target := firstNonEmpty(mapping.Target, mapping.Properties["mapped-id"], mapping.Properties["identifier"])
+	access := ""
+	if strings.TrimSpace(mapping.LUN) == "" {
+		// no-access rows carry no LUN; keep them apart from presented rows.
+		access = strings.ToLower(strings.TrimSpace(mapping.Access))
+	}
+	return strings.ToLower(strings.TrimSpace(volume)) + "|" + strings.ToLower(strings.TrimSpace(target)) + "|" + strings.TrimSpace(mapping.LUN) + "|" + access
+}
+
+func mergePortLists(current, extra string) string {
+	ports := splitList(current)
+	seen := make(map[string]struct{}, len(ports))
+	for _, port := range ports {
+		seen[strings.ToUpper(port)] = struct{}{}
+	}
+	for _, port := range splitList(extra) {
+		if _, ok := seen[strings.ToUpper(port)]; ok {
+			continue
+		}
+		seen[strings.ToUpper(port)] = struct{}{}
+		ports = append(ports, port)
+	}
+	return strings.Join(ports, ",")
+}
+
+// MappingPortLUNs merges the per-port LUNs of every row reported for the same
+// volume and target. Arrays presenting different LUNs on different ports
+// report one row per LUN.
+func MappingPortLUNs(mappings []Mapping) map[string]string {
+	merged := make(map[string]string)
+	for _, mapping := range mappings {
+		for port, lun := range mapping.PortLUNs {
+			merged[port] = lun
+		}
+	}
+	return merged
+}
+
+// HasAsymmetricLUNs reports whether the ports present more than one LUN.
+func HasAsymmetricLUNs(portLUNs map[string]string) bool {
+	return len(DistinctLUNs(portLUNs)) > 1
+}
+
+func DistinctLUNs(portLUNs map[string]string) []string {
+	seen := make(map[string]struct{})
+	luns := make([]string, 0)
+	for _, lun := range portLUNs {
+		if _, ok := seen[lun]; ok {
+			continue
+		}
+		seen[lun] = struct{}{}
+		luns = append(luns, lun)
+	}
+	sort.Strings(luns)
+	return luns
+}
+
+func portLUNs(ports, lun string) map[string]string {
+	result := make(map[string]string)
+	if lun == "" {
+		return result
+	}
+	for _, port := range splitList(ports) {
+		result[strings.ToUpper(port)] = lun
+	}
+	return result
 }
@@ -40,3 +40,140 @@ func TestMappingsFromResponse(t *testing.T) {
 		t.Fatalf("expected empty LUN for no-access, got %q", mappings[1].LUN)
 	}
 }
+
+func TestMappingPortLUNsAsymmetric(t *testing.T) {
+	fixture := readFixture(t, "show_maps_initiator_asymmetric.xml")
+	response, err := parseResponse(fixture)
+	if err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+
+	mappings := MappingsFromResponse(response)
+	if len(mappings) != 2 {
+		t.Fatalf("expected 2 mapping rows, got %d", len(mappings))
+	}
+
+	portLUNs := MappingPortLUNs(mappings)
+	expected := map[string]string{"A1": "10", "B1": "10", "A2": "11", "B2": "11"}
+	if len(portLUNs) != len(expected) {
+		t.Fatalf("expected %d ports, got %v", len(expected), portLUNs)
+	}
+	for port, lun := range expected {
+		if portLUNs[port] != lun {
+			t.Fatalf("port %s: expected LUN %q, got %q", port, lun, portLUNs[port])
+		}
+	}
+	if !HasAsymmetricLUNs(portLUNs) {
+		t.Fatalf("expected asymmetric LUNs to be detected")
+	}
+}
+
+func TestMappingPortLUNsSymmetric(t *testing.T) {
+	fixture := readFixture(t, "show_maps_initiator.xml")
+	response, err := parseResponse(fixture)
+	if err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+
+	mappings := MappingsFromResponse(response)
+	portLUNs := MappingPortLUNs(mappings[:1])
+	if portLUNs["1"] != "12" || portLUNs["2"] != "12" {
+		t.Fatalf("unexpected port LUNs %v", portLUNs)
+	}
+	if HasAsymmetricLUNs(portLUNs) {
+		t.Fatalf("expected symmetric LUNs")
+	}
+	if len(mappings[1].PortLUNs) != 0 {
+		t.Fatalf("expected no port LUNs for no-access mapping, got %v", mappings[1].PortLUNs)
+	}
+}
+
+func TestMappingsByVolumeFromResponse(t *testing.T) {
+	fixture := readFixture(t, "show_maps_volume.xml")
+	response, err := parseResponse(fixture)
+	if err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+
+	mappings := MappingsByVolumeFromResponse(response)
+	if len(mappings) != 3 {
+		t.Fatalf("expected 3 mappings, got %d", len(mappings))
+	}
+
+	group := mappings[0]
+	if group.Volume != "volA" || group.VolumeSerial != "00c0ff3cab9c00000000000002010000" {
+		t.Fatalf("expected parent volume to be inherited, got %q/%q", group.Volume, group.VolumeSerial)
+	}
+	if group.Target != "TestGroup.*.*" || group.LUN != "12" || group.Access != "read-write" {
+		t.Fatalf("unexpected group mapping %+v", group)
+	}
+	if group.PortLUNs["A1"] != "12" || group.PortLUNs["B1"] != "12" {
+		t.Fatalf("unexpected port LUNs %v", group.PortLUNs)
+	}
+
+	if mappings[1].Target != "esx-a.*" || mappings[1].LUN != "20" {
+		t.Fatalf("unexpected host mapping %+v", mappings[1])
+	}
+	if mappings[2].Target != "21000024ff3dfed1" || mappings[2].Access != "no-access" || mappings[2].LUN != "" {
+		t.Fatalf("unexpected no-access mapping %+v", mappings[2])
+	}
+}
+
+func TestMappingsByVolumeIgnoresInitiatorView(t *testing.T) {
+	fixture := readFixture(t, "show_maps_initiator.xml")
+	response, err := parseResponse(fixture)
+	if err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+
+	if mappings := MappingsByVolumeFromResponse(response); len(mappings) != 0 {
+		t.Fatalf("expected no volume-keyed rows in an initiator view, got %+v", mappings)
+	}
+}
+
+func TestMappingsByVolumeDedupesPerControllerRows(t *testing.T) {
+	response := mustParseFixture(t, "show_maps_duplicated.xml")
+
+	mappings := MappingsByVolumeFromResponse(response)
+	if len(mappings) != 2 {
+		t.Fatalf("expected 2 logical mappings, got %d: %+v", len(mappings), mappings)
+	}
+	group := mappings[0]
+	if group.Target != "TestGroup.*.*" || group.LUN != "7" {
+		t.Fatalf("unexpected first mapping %+v", group)
+	}
+	if group.Ports != "A1,A2,B1,B2" {
+		t.Fatalf("expected ports of both controllers to be merged, got %q", group.Ports)
+	}
+	if len(group.PortLUNs) != 4 || group.PortLUNs["B2"] != "7" {
+		t.Fatalf("expected per-port LUNs of both controllers, got %v", group.PortLUNs)
+	}
+	if mappings[1].Target != "esx-a.*" || mappings[1].Ports != "A1,B1" {
+		t.Fatalf("unexpected second mapping %+v", mappings[1])
+	}
+}
+
+func TestMappingsFromResponseDedupesRepeatedRows(t *testing.T) {
+	row := func(ports string) Object {
+		return Object{BaseType: "host-view-mappings", Properties: []Property{
+			{Name: "volume", Value: "vol1"},
+			{Name: "lun", Value: "3"},
+			{Name: "access", Value: "read-write"},
+			{Name: "ports", Value: ports},
+		}}
+	}
+	response := Response{Objects: []Object{row("A1"), row("B1"), {BaseType: "host-view-mappings", Properties: []Property{
+		{Name: "volume", Value: "vol1"},
+		{Name: "lun", Value: "4"},
+		{Name: "access", Value: "read-write"},
+		{Name: "ports", Value: "A1"},
+	}}}}
+
+	mappings := MappingsFromResponse(response)
+	if len(mappings) != 2 {
+		t.Fatalf("expected repeated LUN 3 rows to collapse, got %+v", mappings)
+	}
+	if mappings[0].Ports != "A1,B1" || mappings[1].LUN != "4" {
+		t.Fatalf("unexpected mappings %+v", mappings)
+	}
+}
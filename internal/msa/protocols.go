@@ -0,0 +1,82 @@
+package msa
+
+import (
+	"sort"
+	"strings"
+)
+
+// protocolProperties maps each `set protocols` parameter to the property
+// names `show protocols` reports for it across firmware revisions.
+var protocolProperties = map[string][]string{
+	"activity": {"activity-progress", "activity"},
+	"debug":    {"debug-interface", "debug"},
+	"ftp":      {"ftp"},
+	"http":     {"wbi-http", "http"},
+	"https":    {"wbi-https", "https"},
+	"ses":      {"inband-ses", "ses"},
+	"sftp":     {"sftp"},
+	"slp":      {"slp"},
+	"smis":     {"smis"},
+	"snmp":     {"snmp"},
+	"ssh":      {"cli-ssh", "ssh"},
+	"telnet":   {"cli-telnet", "telnet"},
+	"usmis":    {"usmis"},
+}
+
+type Protocols struct {
+	Enabled    map[string]bool
+	Properties map[string]string
+}
+
+// ProtocolNames returns the `set protocols` parameters known to the parser.
+func ProtocolNames() []string {
+	names := make([]string, 0, len(protocolProperties))
+	for name := range protocolProperties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func IsKnownProtocol(name string) bool {
+	_, ok := protocolProperties[strings.ToLower(strings.TrimSpace(name))]
+	return ok
+}
+
+func ProtocolsFromResponse(response Response) (Protocols, bool) {
+	for _, obj := range response.ObjectsWithoutStatus() {
+		if !isProtocolsObject(obj) {
+			continue
+		}
+		return protocolsFromObject(obj), true
+	}
+	return Protocols{}, false
+}
+
+func isProtocolsObject(obj Object) bool {
+	if obj.BaseType == "security-communications-protocols" {
+		return true
+	}
+	_, ok := obj.PropertyValue("cli-ssh")
+	return ok
+}
+
+func protocolsFromObject(obj Object) Protocols {
+	props := obj.PropertyMap()
+	enabled := make(map[string]bool)
+	for name, keys := range protocolProperties {
+		for _, key := range keys {
+			value, ok := props[key]
+			if !ok || strings.TrimSpace(value) == "" {
+				continue
+			}
+			enabled[name] = isEnabledFlag(value)
+			break
+		}
+	}
+
+	return Protocols{
+		Enabled:    enabled,
+		Properties: props,
+	}
+}
@@ -0,0 +1,56 @@
+package msa
+
+import "testing"
+
+func TestProtocolsFromResponse(t *testing.T) {
+	fixture := readFixture(t, "show_protocols.xml")
+	response, err := parseResponse(fixture)
+	if err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+
+	protocols, ok := ProtocolsFromResponse(response)
+	if !ok {
+		t.Fatalf("expected protocols object")
+	}
+
+	expected := map[string]bool{
+		"activity": false,
+		"debug":    false,
+		"ftp":      false,
+		"http":     false,
+		"https":    true,
+		"ses":      true,
+		"sftp":     true,
+		"slp":      true,
+		"smis":     false,
+		"snmp":     true,
+		"ssh":      true,
+		"telnet":   false,
+		"usmis":    false,
+	}
+	if len(protocols.Enabled) != len(expected) {
+		t.Fatalf("expected %d protocols, got %d (%v)", len(expected), len(protocols.Enabled), protocols.Enabled)
+	}
+	for name, want := range expected {
+		got, ok := protocols.Enabled[name]
+		if !ok {
+			t.Fatalf("missing protocol %q", name)
+		}
+		if got != want {
+			t.Fatalf("protocol %q: expected %v, got %v", name, want, got)
+		}
+	}
+}
+
+func TestIsKnownProtocol(t *testing.T) {
+	if !IsKnownProtocol("Telnet") {
+		t.Fatalf("expected telnet to be known")
+	}
+	if IsKnownProtocol("gopher") {
+		t.Fatalf("expected gopher to be unknown")
+	}
+	if len(ProtocolNames()) != len(protocolProperties) {
+		t.Fatalf("unexpected protocol name count")
+	}
+}
@@ -2,8 +2,14 @@ package msa
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
 	"math"
 	"math/rand"
+	"syscall"
 	"time"
 )
 
@@ -34,11 +40,22 @@ func doWithRetry(ctx context.Context, config RetryConfig, fn func() (bool, error
 	var lastErr error
 
 	for attempt := 1; attempt <= config.MaxAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		retry, err := fn()
 		if err == nil {
 			return nil
 		}
 		lastErr = err
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			// Report the cancellation rather than backing off and retrying;
+			// keep the attempt's error when it does not already carry it.
+			if errors.Is(err, ctxErr) {
+				return err
+			}
+			return fmt.Errorf("%w (last attempt: %v)", ctxErr, err)
+		}
 		if !retry || attempt == config.MaxAttempts {
 			break
 		}
@@ -79,3 +96,36 @@ func isRetryableStatus(status int) bool {
 		return false
 	}
 }
+
+// IsTLSVerificationError reports whether err is a certificate verification
+// failure. These are configuration problems and are never retried.
+func IsTLSVerificationError(err error) bool {
+	var verifyErr *tls.CertificateVerificationError
+	var unknownAuthority x509.UnknownAuthorityError
+	var invalidCert x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+	return errors.As(err, &verifyErr) ||
+		errors.As(err, &unknownAuthority) ||
+		errors.As(err, &invalidCert) ||
+		errors.As(err, &hostnameErr)
+}
+
+// isRetryableTransportError treats transport failures (including transient
+// TLS handshake errors and per-request http.Client timeouts) as retryable,
+// except certificate problems and cancellation of the caller's context.
+func isRetryableTransportError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	return !IsTLSVerificationError(err)
+}
+
+// isBrokenConnectionError reports whether err means the connection itself
+// failed (reset, closed mid-response), in which case other pooled
+// connections to the array are likely stale too.
+func isBrokenConnectionError(err error) bool {
+	return errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE)
+}
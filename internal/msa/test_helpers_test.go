@@ -16,3 +16,12 @@ func readFixture(t *testing.T, name string) []byte {
 	}
 	return data
 }
+
+func mustParseFixture(t *testing.T, name string) Response {
+	t.Helper()
+	response, err := parseResponse(readFixture(t, name))
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", name, err)
+	}
+	return response
+}
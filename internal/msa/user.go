@@ -0,0 +1,114 @@
+package msa
+
+import (
+	"sort"
+	"strings"
+)
+
+type User struct {
+	Name       string
+	Roles      []string
+	Interfaces []string
+	UserType   string
+	Locale     string
+	Properties map[string]string
+}
+
+func UsersFromResponse(response Response) []User {
+	users := make([]User, 0)
+	for _, obj := range response.ObjectsWithoutStatus() {
+		if !isUserObject(obj) {
+			continue
+		}
+		users = append(users, userFromObject(obj))
+	}
+	return users
+}
+
+// HasRole reports whether the user holds the named role (e.g. "manage").
+func (u User) HasRole(role string) bool {
+	role = strings.TrimSpace(role)
+	for _, candidate := range u.Roles {
+		if strings.EqualFold(candidate, role) {
+			return true
+		}
+	}
+	return false
+}
+
+func isUserObject(obj Object) bool {
+	if obj.BaseType == "users" || obj.BaseType == "user" {
+		return true
+	}
+	_, ok := obj.PropertyValue("username")
+	return ok
+}
+
+func userFromObject(obj Object) User {
+	props := obj.PropertyMap()
+
+	return User{
+		Name:       strings.TrimSpace(firstNonEmpty(props["username"], props["user-name"])),
+		Roles:      splitList(props["roles"]),
+		Interfaces: userInterfaces(props),
+		UserType:   strings.TrimSpace(props["user-type"]),
+		Locale:     strings.TrimSpace(props["user-locale"]),
+		Properties: props,
+	}
+}
+
+// userInterfaces accepts both the flattened "interface-access-<name>" flags
+// reported by most firmware and a single comma-separated "interface-access".
+func userInterfaces(props map[string]string) []string {
+	seen := make(map[string]struct{})
+	interfaces := make([]string, 0)
+	add := func(value string) {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			return
+		}
+		if _, ok := seen[value]; ok {
+			return
+		}
+		seen[value] = struct{}{}
+		interfaces = append(interfaces, value)
+	}
+
+	for _, value := range splitList(props["interface-access"]) {
+		add(value)
+	}
+	for key, value := range props {
+		lower := strings.ToLower(key)
+		if !strings.HasPrefix(lower, "interface-access-") {
+			continue
+		}
+		if isEnabledFlag(value) {
+			add(strings.TrimPrefix(lower, "interface-access-"))
+		}
+	}
+
+	sort.Strings(interfaces)
+	return interfaces
+}
+
+func isEnabledFlag(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "x", "true", "yes", "enabled", "on", "1":
+		return true
+	default:
+		return false
+	}
+}
+
+func splitList(value string) []string {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	items := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if trimmed := strings.TrimSpace(field); trimmed != "" {
+			items = append(items, trimmed)
+		}
+	}
+	return items
+}
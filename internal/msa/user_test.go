@@ -0,0 +1,52 @@
+package msa
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUsersFromResponse(t *testing.T) {
+	fixture := readFixture(t, "show_users.xml")
+	response, err := parseResponse(fixture)
+	if err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+
+	users := UsersFromResponse(response)
+	if len(users) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(users))
+	}
+
+	manage := users[0]
+	if manage.Name != "manage" {
+		t.Fatalf("expected manage user, got %q", manage.Name)
+	}
+	if !reflect.DeepEqual(manage.Roles, []string{"manage", "monitor"}) {
+		t.Fatalf("unexpected roles %v", manage.Roles)
+	}
+	if !reflect.DeepEqual(manage.Interfaces, []string{"cli", "wbi"}) {
+		t.Fatalf("unexpected interfaces %v", manage.Interfaces)
+	}
+	if !manage.HasRole("Manage") {
+		t.Fatalf("expected manage role to be detected")
+	}
+	if manage.UserType != "Standard" || manage.Locale != "English" {
+		t.Fatalf("unexpected user type/locale %q/%q", manage.UserType, manage.Locale)
+	}
+
+	monitor := users[1]
+	if monitor.HasRole("manage") {
+		t.Fatalf("expected monitor user without manage role")
+	}
+	if !reflect.DeepEqual(monitor.Interfaces, []string{"wbi"}) {
+		t.Fatalf("unexpected interfaces %v", monitor.Interfaces)
+	}
+}
+
+func TestUserInterfacesCommaList(t *testing.T) {
+	got := userInterfaces(map[string]string{"interface-access": "WBI, CLI, ftp"})
+	want := []string{"cli", "ftp", "wbi"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
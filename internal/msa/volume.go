@@ -1,6 +1,15 @@
 package msa
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultBlockSize = 512
+	// virtualPageSize is the allocation unit of virtual pools (4 MiB pages).
+	virtualPageSize = 4 << 20
+)
 
 type Volume struct {
 	Name         string
@@ -11,7 +20,10 @@ type Volume struct {
 	VDiskName    string
 	Size         string
 	SizeNumeric  string
-	Properties   map[string]string
+	// ReadAheadSize is the cache read-ahead setting as reported (e.g.
+	// "Adaptive", "Disabled", "1MB").
+	ReadAheadSize string
+	Properties    map[string]string
 }
 
 func VolumesFromResponse(response Response) []Volume {
@@ -37,16 +49,100 @@ func volumeFromObject(obj Object) Volume {
 	props := obj.PropertyMap()
 
 	return Volume{
-		Name:         firstNonEmpty(props["volume-name"], props["name"], obj.Name),
-		SerialNumber: props["serial-number"],
-		DurableID:    props["durable-id"],
-		WWN:          firstNonEmpty(props["wwn"], props["volume-wwn"], props["volume-wwid"]),
-		PoolName:     firstNonEmpty(props["storage-pool-name"], props["storage-poolname"], props["pool-name"]),
-		VDiskName:    firstNonEmpty(props["virtual-disk-name"], props["virtual-diskname"], props["vdisk-name"]),
-		Size:         props["size"],
-		SizeNumeric:  props["size-numeric"],
-		Properties:   props,
+		Name:          firstNonEmpty(props["volume-name"], props["name"], obj.Name),
+		SerialNumber:  props["serial-number"],
+		DurableID:     props["durable-id"],
+		WWN:           firstNonEmpty(props["wwn"], props["volume-wwn"], props["volume-wwid"]),
+		PoolName:      firstNonEmpty(props["storage-pool-name"], props["storage-poolname"], props["pool-name"]),
+		VDiskName:     firstNonEmpty(props["virtual-disk-name"], props["virtual-diskname"], props["vdisk-name"]),
+		Size:          props["size"],
+		SizeNumeric:   props["size-numeric"],
+		ReadAheadSize: strings.TrimSpace(props["read-ahead-size"]),
+		Properties:    props,
+	}
+}
+
+// AllocatedBytes returns the space actually allocated to the volume, which
+// for thin volumes is below the advertised size. allocated-size-numeric is
+// reported in blocks.
+func (v Volume) AllocatedBytes() (int64, bool) {
+	blocks, ok := parseUint(v.Properties["allocated-size-numeric"])
+	if !ok {
+		return 0, false
+	}
+	return blocks * v.BlockSize(), true
+}
+
+// BlockSize returns the volume's logical block size in bytes, defaulting to
+// 512 when the array does not report it.
+func (v Volume) BlockSize() int64 {
+	blockSize, ok := parseUint(v.Properties["blocksize"])
+	if !ok || blockSize == 0 {
+		return defaultBlockSize
+	}
+	return blockSize
+}
+
+// VolumeType returns the lowercased volume-type the array reports (e.g.
+// "base", "standard", "snapshot"). Firmware that omits it gets "snapshot" for
+// volumes with a parent and "base" otherwise.
+func (v Volume) VolumeType() string {
+	if volumeType := strings.ToLower(strings.TrimSpace(v.Properties["volume-type"])); volumeType != "" {
+		return volumeType
+	}
+	if v.IsSnapshot() {
+		return "snapshot"
+	}
+	return "base"
+}
+
+// IsSnapshot reports whether the volume is a snapshot, from volume-type, the
+// snapshot flag, or a parent volume.
+func (v Volume) IsSnapshot() bool {
+	if strings.Contains(strings.ToLower(v.Properties["volume-type"]), "snapshot") {
+		return true
+	}
+	switch strings.ToLower(strings.TrimSpace(v.Properties["snapshot"])) {
+	case "yes", "true":
+		return true
+	}
+	return v.Parent() != ""
+}
+
+// Parent returns the volume a snapshot was taken from. volume-parent may be
+// a serial number rather than a name. Base volumes, which some firmware lists
+// as their own base-volume, have no parent.
+func (v Volume) Parent() string {
+	parent := strings.TrimSpace(firstNonEmpty(v.Properties["volume-parent"], v.Properties["base-volume"], v.Properties["master-volume-name"]))
+	if parent == "" || parent == "N/A" || strings.EqualFold(parent, v.Name) || strings.EqualFold(parent, v.SerialNumber) {
+		return ""
+	}
+	return parent
+}
+
+// AllocatedPages returns the number of pool pages backing the volume, using
+// the array's count when reported and deriving it from AllocatedBytes otherwise.
+func (v Volume) AllocatedPages() (int64, bool) {
+	if pages, ok := parseUint(v.Properties["allocated-pages"]); ok {
+		return pages, true
+	}
+	allocated, ok := v.AllocatedBytes()
+	if !ok {
+		return 0, false
+	}
+	return (allocated + virtualPageSize - 1) / virtualPageSize, true
+}
+
+func parseUint(value string) (int64, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed < 0 {
+		return 0, false
 	}
+	return parsed, true
 }
 
 func firstNonEmpty(values ...string) string {
@@ -72,15 +72,18 @@ var volumeCopyProgressKeys = []string{
 }
 
 type VolumeCopyJob struct {
-	ID         string
-	Source     string
-	Target     string
-	Status     string
-	ETARaw     string
-	ETA        time.Duration
-	HasETA     bool
-	Active     bool
-	Properties map[string]string
+	ID     string
+	Source string
+	Target string
+	Status string
+	ETARaw string
+	ETA    time.Duration
+	HasETA bool
+	// Progress is the percent complete when the array reports it.
+	Progress    float64
+	HasProgress bool
+	Active      bool
+	Properties  map[string]string
 }
 
 func (c *Client) FindActiveVolumeCopyJob(ctx context.Context, sourceHint, targetHint string) (*VolumeCopyJob, error) {
@@ -185,17 +188,20 @@ func volumeCopyJobFromObject(obj Object) VolumeCopyJob {
 	etaRaw := firstPropertyValue(props, volumeCopyETAKeys...)
 	eta, hasETA := parseVolumeCopyETA(etaRaw)
 	status := firstPropertyValue(props, volumeCopyStatusKeys...)
+	progress, hasProgress := parseProgressPercent(firstPropertyValue(props, volumeCopyProgressKeys...))
 
 	job := VolumeCopyJob{
-		ID:         firstNonEmpty(firstPropertyValue(props, volumeCopyJobIDKeys...), strings.TrimSpace(obj.OID)),
-		Source:     firstPropertyValue(props, volumeCopySourceKeys...),
-		Target:     firstPropertyValue(props, volumeCopyTargetKeys...),
-		Status:     status,
-		ETARaw:     etaRaw,
-		ETA:        eta,
-		HasETA:     hasETA,
-		Active:     isVolumeCopyJobActive(status, props),
-		Properties: props,
+		ID:          firstNonEmpty(firstPropertyValue(props, volumeCopyJobIDKeys...), strings.TrimSpace(obj.OID)),
+		Source:      firstPropertyValue(props, volumeCopySourceKeys...),
+		Target:      firstPropertyValue(props, volumeCopyTargetKeys...),
+		Status:      status,
+		ETARaw:      etaRaw,
+		ETA:         eta,
+		HasETA:      hasETA,
+		Progress:    progress,
+		HasProgress: hasProgress,
+		Active:      isVolumeCopyJobActive(status, props),
+		Properties:  props,
 	}
 
 	return job
@@ -0,0 +1,64 @@
+package msa
+
+import (
+	"sort"
+	"strings"
+)
+
+type VolumeStatistics struct {
+	Name           string
+	SerialNumber   string
+	ReadHits       int64
+	WriteHits      int64
+	IOPS           int64
+	BytesPerSecond int64
+	Properties     map[string]string
+}
+
+// VolumeStatisticsFromResponse parses `show volume-statistics`, sorted by
+// volume name.
+func VolumeStatisticsFromResponse(response Response) []VolumeStatistics {
+	stats := make([]VolumeStatistics, 0)
+	for _, obj := range response.ObjectsWithoutStatus() {
+		if !isVolumeStatisticsObject(obj) {
+			continue
+		}
+		stats = append(stats, volumeStatisticsFromObject(obj))
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].Name < stats[j].Name
+	})
+	return stats
+}
+
+func isVolumeStatisticsObject(obj Object) bool {
+	if obj.BaseType == "volume-statistics" {
+		return true
+	}
+	_, hasHits := obj.PropertyValue("read-cache-hits")
+	_, hasName := obj.PropertyValue("volume-name")
+	return hasHits && hasName
+}
+
+func volumeStatisticsFromObject(obj Object) VolumeStatistics {
+	props := obj.PropertyMap()
+
+	return VolumeStatistics{
+		Name:           strings.TrimSpace(firstNonEmpty(props["volume-name"], props["name"])),
+		SerialNumber:   strings.TrimSpace(props["serial-number"]),
+		ReadHits:       firstCounter(props, "read-cache-hits", "read-hits"),
+		WriteHits:      firstCounter(props, "write-cache-hits", "write-hits"),
+		IOPS:           firstCounter(props, "iops"),
+		BytesPerSecond: firstCounter(props, "bytes-per-second-numeric", "bytes-per-second"),
+		Properties:     props,
+	}
+}
+
+func firstCounter(props map[string]string, keys ...string) int64 {
+	for _, key := range keys {
+		if value, ok := parseUint(props[key]); ok {
+			return value
+		}
+	}
+	return 0
+}
@@ -0,0 +1,24 @@
+package msa
+
+import "testing"
+
+func TestVolumeStatisticsFromResponse(t *testing.T) {
+	stats := VolumeStatisticsFromResponse(mustParseFixture(t, "show_volume_statistics.xml"))
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 volume statistics, got %d", len(stats))
+	}
+	if stats[0].Name != "vol01" || stats[1].Name != "vol02" {
+		t.Fatalf("expected statistics sorted by name, got %q, %q", stats[0].Name, stats[1].Name)
+	}
+
+	busy := stats[1]
+	if busy.ReadHits != 4100 || busy.WriteHits != 2400 {
+		t.Fatalf("unexpected cache hits read=%d write=%d", busy.ReadHits, busy.WriteHits)
+	}
+	if busy.IOPS != 120 || busy.BytesPerSecond != 1048576 {
+		t.Fatalf("unexpected throughput iops=%d bps=%d", busy.IOPS, busy.BytesPerSecond)
+	}
+	if busy.SerialNumber != "00c0ff3cab9c00000000000002020000" {
+		t.Fatalf("unexpected serial %q", busy.SerialNumber)
+	}
+}
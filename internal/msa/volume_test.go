@@ -33,4 +33,69 @@ func TestVolumesFromResponse(t *testing.T) {
 	if volume.VDiskName != "pool-a" {
 		t.Fatalf("unexpected vdisk name: %s", volume.VDiskName)
 	}
+	if volume.ReadAheadSize != "Adaptive" {
+		t.Fatalf("unexpected read-ahead size: %s", volume.ReadAheadSize)
+	}
+}
+
+func TestVolumeAllocationThin(t *testing.T) {
+	volumes := VolumesFromResponse(mustParseFixture(t, "show_volumes_thin.xml"))
+	if len(volumes) != 2 {
+		t.Fatalf("expected 2 volumes, got %d", len(volumes))
+	}
+
+	thin := volumes[0]
+	allocated, ok := thin.AllocatedBytes()
+	if !ok || allocated != 10_000_000_000 {
+		t.Fatalf("unexpected allocated bytes %d (%v)", allocated, ok)
+	}
+	pages, ok := thin.AllocatedPages()
+	if !ok || pages != 2385 {
+		t.Fatalf("unexpected derived allocated pages %d (%v)", pages, ok)
+	}
+	advertised, _ := parseUint(thin.SizeNumeric)
+	if allocated >= advertised*defaultBlockSize {
+		t.Fatalf("expected thin volume to be partially allocated")
+	}
+
+	full := volumes[1]
+	pages, ok = full.AllocatedPages()
+	if !ok || pages != 11921 {
+		t.Fatalf("expected reported allocated pages, got %d (%v)", pages, ok)
+	}
+
+	legacy := VolumesFromResponse(mustParseFixture(t, "show_volumes.xml"))[0]
+	if _, ok := legacy.AllocatedBytes(); ok {
+		t.Fatalf("expected no allocation data without allocated-size-numeric")
+	}
+}
+
+func TestVolumeTypeDistinguishesSnapshots(t *testing.T) {
+	volumes := VolumesFromResponse(mustParseFixture(t, "show_volumes_snapshot.xml"))
+	if len(volumes) != 2 {
+		t.Fatalf("expected 2 volumes, got %d", len(volumes))
+	}
+
+	base := volumes[0]
+	if base.VolumeType() != "base" || base.IsSnapshot() || base.Parent() != "" {
+		t.Fatalf("expected base volume, got type=%q snapshot=%v parent=%q", base.VolumeType(), base.IsSnapshot(), base.Parent())
+	}
+
+	snapshot := volumes[1]
+	if snapshot.VolumeType() != "snapshot" || !snapshot.IsSnapshot() {
+		t.Fatalf("expected snapshot, got type=%q snapshot=%v", snapshot.VolumeType(), snapshot.IsSnapshot())
+	}
+	if snapshot.Parent() != base.SerialNumber {
+		t.Fatalf("expected parent %q, got %q", base.SerialNumber, snapshot.Parent())
+	}
+
+	legacy := VolumesFromResponse(mustParseFixture(t, "show_volumes.xml"))[0]
+	if legacy.VolumeType() != "base" || legacy.IsSnapshot() {
+		t.Fatalf("expected a volume without type properties to be a base volume, got %q", legacy.VolumeType())
+	}
+
+	selfBased := Volume{Name: "vol01", Properties: map[string]string{"base-volume": "vol01"}}
+	if selfBased.IsSnapshot() || selfBased.Parent() != "" {
+		t.Fatalf("expected a volume listed as its own base to have no parent")
+	}
 }
@@ -1,7 +1,11 @@
 package msa
 
 import (
+	"bytes"
 	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
 	"strconv"
 	"strings"
 )
@@ -36,6 +40,54 @@ type Status struct {
 	TimeStamp           string
 }
 
+// parseResponseLenient decodes the first RESPONSE element anywhere in the
+// document, matching its name case-insensitively and ignoring namespaces and
+// wrapper elements. HTML entities and Latin-1 documents are accepted.
+func parseResponseLenient(body []byte) (Response, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	decoder.CharsetReader = latin1CharsetReader
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return Response{}, errors.New("no RESPONSE element in document")
+			}
+			return Response{}, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || !strings.EqualFold(start.Name.Local, "RESPONSE") {
+			continue
+		}
+		start.Name = xml.Name{Local: "RESPONSE"}
+		var response Response
+		if err := decoder.DecodeElement(&response, &start); err != nil {
+			return Response{}, err
+		}
+		return response, nil
+	}
+}
+
+func latin1CharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "iso-8859-1", "iso8859-1", "latin1", "latin-1", "us-ascii", "ascii":
+	default:
+		return nil, fmt.Errorf("unsupported XML encoding %q", charset)
+	}
+	raw, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	var decoded strings.Builder
+	decoded.Grow(len(raw))
+	for _, b := range raw {
+		decoded.WriteRune(rune(b))
+	}
+	return strings.NewReader(decoded.String()), nil
+}
+
 func (o Object) PropertyValue(name string) (string, bool) {
 	for _, prop := range o.Properties {
 		if prop.Name == name {
@@ -47,32 +99,52 @@ func (o Object) PropertyValue(name string) (string, bool) {
 
 func (r Response) Status() (Status, bool) {
 	for _, obj := range r.AllObjects() {
-		if obj.BaseType == "status" || obj.Name == "status" {
-			status := Status{}
-			if value, ok := obj.PropertyValue("response-type"); ok {
-				status.ResponseType = value
-			}
-			if value, ok := obj.PropertyValue("response-type-numeric"); ok {
-				status.ResponseTypeNumeric = parseInt(value)
-			}
-			if value, ok := obj.PropertyValue("response"); ok {
-				status.Response = value
-			}
-			if value, ok := obj.PropertyValue("return-code"); ok {
-				status.ReturnCode = parseInt(value)
-			}
-			if value, ok := obj.PropertyValue("component-id"); ok {
-				status.ComponentID = value
-			}
-			if value, ok := obj.PropertyValue("time-stamp"); ok {
-				status.TimeStamp = value
-			}
-			return status, true
+		if isStatusObject(obj) {
+			return statusFromObject(obj), true
 		}
 	}
 	return Status{}, false
 }
 
+// Statuses returns every status object in document order. Bulk commands
+// report one status per item, so the first status alone can hide failures.
+func (r Response) Statuses() []Status {
+	statuses := make([]Status, 0, 1)
+	for _, obj := range r.AllObjects() {
+		if isStatusObject(obj) {
+			statuses = append(statuses, statusFromObject(obj))
+		}
+	}
+	return statuses
+}
+
+func isStatusObject(obj Object) bool {
+	return obj.BaseType == "status" || obj.Name == "status"
+}
+
+func statusFromObject(obj Object) Status {
+	status := Status{}
+	if value, ok := obj.PropertyValue("response-type"); ok {
+		status.ResponseType = value
+	}
+	if value, ok := obj.PropertyValue("response-type-numeric"); ok {
+		status.ResponseTypeNumeric = parseInt(value)
+	}
+	if value, ok := obj.PropertyValue("response"); ok {
+		status.Response = value
+	}
+	if value, ok := obj.PropertyValue("return-code"); ok {
+		status.ReturnCode = parseInt(value)
+	}
+	if value, ok := obj.PropertyValue("component-id"); ok {
+		status.ComponentID = value
+	}
+	if value, ok := obj.PropertyValue("time-stamp"); ok {
+		status.TimeStamp = value
+	}
+	return status
+}
+
 func (s Status) Success() bool {
 	if s.ResponseTypeNumeric == 1 || strings.EqualFold(s.ResponseType, "error") {
 		return false
@@ -54,3 +54,51 @@ func TestStatusSuccess(t *testing.T) {
 		})
 	}
 }
+
+func TestParseResponseOEMWrapper(t *testing.T) {
+	response, err := parseResponse(readFixture(t, "show_volumes_oem_wrapped.xml"))
+	if err != nil {
+		t.Fatalf("failed to parse wrapped response: %v", err)
+	}
+
+	volumes := VolumesFromResponse(response)
+	if len(volumes) != 1 || volumes[0].Name != "vol01" || volumes[0].PoolName != "pool-a" {
+		t.Fatalf("unexpected volumes %+v", volumes)
+	}
+	if got := volumes[0].Properties["volume-description"]; got != "Base de données prod" {
+		t.Fatalf("expected Latin-1 text and entities to be decoded, got %q", got)
+	}
+	status, ok := response.Status()
+	if !ok || !status.Success() {
+		t.Fatalf("expected a successful status, got %+v (%v)", status, ok)
+	}
+}
+
+func TestParseResponseLenientVariants(t *testing.T) {
+	const objects = `<OBJECT basetype="volumes" name="volume"><PROPERTY name="volume-name">vol01</PROPERTY></OBJECT>`
+	tests := map[string]string{
+		"prefixed root":  `<msa:RESPONSE xmlns:msa="urn:example" VERSION="L100">` + objects + `</msa:RESPONSE>`,
+		"default ns":     `<RESPONSE xmlns="urn:example" VERSION="L100">` + objects + `</RESPONSE>`,
+		"wrapper":        `<DATA><RESPONSE VERSION="L100">` + objects + `</RESPONSE></DATA>`,
+		"lowercase root": `<response VERSION="L100">` + objects + `</response>`,
+		"html entity":    `<RESPONSE VERSION="L100"><OBJECT basetype="volumes" name="volume"><PROPERTY name="volume-name">vol01</PROPERTY><PROPERTY name="note">a&nbsp;b</PROPERTY></OBJECT></RESPONSE>`,
+	}
+	for name, body := range tests {
+		t.Run(name, func(t *testing.T) {
+			response, err := parseResponse([]byte(body))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			volumes := VolumesFromResponse(response)
+			if len(volumes) != 1 || volumes[0].Name != "vol01" {
+				t.Fatalf("unexpected volumes %+v", volumes)
+			}
+		})
+	}
+
+	for _, body := range []string{`<DATA><OBJECT/></DATA>`, `not xml`} {
+		if _, err := parseResponse([]byte(body)); err == nil {
+			t.Fatalf("expected %q to fail without a RESPONSE element", body)
+		}
+	}
+}
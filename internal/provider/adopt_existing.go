@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type reconcileOutcome int
+
+const (
+	// reconcileCreate means nothing with the name exists yet.
+	reconcileCreate reconcileOutcome = iota
+	// reconcileAdopt means an identical object exists and adopt_existing
+	// allows taking it over instead of creating.
+	reconcileAdopt
+	// reconcileConflict means an object exists that cannot be adopted; the
+	// returned diagnostics explain why.
+	reconcileConflict
+)
+
+// existingObjectCheck describes the targeted existence read a Create runs
+// before its mutating command.
+type existingObjectCheck[T any] struct {
+	// Kind is the object type used in diagnostics, e.g. "Volume".
+	Kind string
+	Name string
+	// Adopt is the resource's adopt_existing flag.
+	Adopt bool
+	// Find reads the object by name and returns NotFound when it is absent.
+	Find     func(context.Context) (*T, error)
+	NotFound error
+	// Differences lists how the existing object differs from the desired
+	// spec; an empty result means it matches.
+	Differences func(*T) []string
+}
+
+// reconcileBeforeCreate makes Create idempotent: a retried apply after an
+// interrupted create, or an object created out of band, is found by name
+// before anything is sent to the array. A matching object is adopted when
+// adopt_existing is set; anything else with the name is a conflict.
+func reconcileBeforeCreate[T any](ctx context.Context, check existingObjectCheck[T]) (*T, reconcileOutcome, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	existing, err := check.Find(ctx)
+	if err != nil {
+		if errors.Is(err, check.NotFound) {
+			return nil, reconcileCreate, diags
+		}
+		diags.AddError(fmt.Sprintf("Unable to check existing %ss", strings.ToLower(check.Kind)), err.Error())
+		return nil, reconcileConflict, diags
+	}
+
+	if differences := check.Differences(existing); len(differences) > 0 {
+		diags.AddError(
+			fmt.Sprintf("%s already exists", check.Kind),
+			fmt.Sprintf("%s %q already exists with different settings: %s. Import it, choose a different name, or change it on the array.",
+				check.Kind, check.Name, strings.Join(differences, "; ")),
+		)
+		return existing, reconcileConflict, diags
+	}
+	if !check.Adopt {
+		diags.AddError(
+			fmt.Sprintf("%s already exists", check.Kind),
+			fmt.Sprintf("%s %q already exists and matches the configuration. Set adopt_existing = true to adopt it, or import it.",
+				check.Kind, check.Name),
+		)
+		return existing, reconcileConflict, diags
+	}
+
+	tflog.Info(ctx, "object already exists and matches the configuration; adopting it", map[string]any{
+		"kind": check.Kind,
+		"name": check.Name,
+	})
+	return existing, reconcileAdopt, diags
+}
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestReconcileBeforeCreate(t *testing.T) {
+	type object struct {
+		name string
+		pool string
+	}
+	errObjectNotFound := errors.New("object not found")
+	check := func(found *object, findErr error, adopt bool) existingObjectCheck[object] {
+		return existingObjectCheck[object]{
+			Kind:  "Volume",
+			Name:  "vol01",
+			Adopt: adopt,
+			Find: func(context.Context) (*object, error) {
+				return found, findErr
+			},
+			NotFound: errObjectNotFound,
+			Differences: func(existing *object) []string {
+				if existing.pool != "A" {
+					return []string{`pool is "` + existing.pool + `", want "A"`}
+				}
+				return nil
+			},
+		}
+	}
+
+	cases := []struct {
+		name    string
+		check   existingObjectCheck[object]
+		outcome reconcileOutcome
+		adopted bool
+		summary string
+		detail  string
+	}{
+		{
+			name:    "creates when nothing exists",
+			check:   check(nil, errObjectNotFound, true),
+			outcome: reconcileCreate,
+		},
+		{
+			name:    "adopts a matching object",
+			check:   check(&object{name: "vol01", pool: "A"}, nil, true),
+			outcome: reconcileAdopt,
+			adopted: true,
+		},
+		{
+			name:    "requires adopt_existing for a matching object",
+			check:   check(&object{name: "vol01", pool: "A"}, nil, false),
+			outcome: reconcileConflict,
+			summary: "Volume already exists",
+			detail:  "adopt_existing = true",
+		},
+		{
+			name:    "reports differences even with adopt_existing",
+			check:   check(&object{name: "vol01", pool: "B"}, nil, true),
+			outcome: reconcileConflict,
+			summary: "Volume already exists",
+			detail:  `pool is "B", want "A"`,
+		},
+		{
+			name:    "fails when the lookup fails",
+			check:   check(nil, errors.New("connection reset"), true),
+			outcome: reconcileConflict,
+			summary: "Unable to check existing volumes",
+			detail:  "connection reset",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			existing, outcome, diags := reconcileBeforeCreate(context.Background(), tc.check)
+			if outcome != tc.outcome {
+				t.Fatalf("expected outcome %d, got %d", tc.outcome, outcome)
+			}
+			if tc.adopted != (outcome == reconcileAdopt && existing != nil) {
+				t.Fatalf("unexpected adopted object %+v", existing)
+			}
+			if tc.summary == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if !diags.HasError() {
+				t.Fatalf("expected an error diagnostic")
+			}
+			got := diags.Errors()[0]
+			if got.Summary() != tc.summary || !strings.Contains(got.Detail(), tc.detail) {
+				t.Fatalf("unexpected diagnostic %q: %q", got.Summary(), got.Detail())
+			}
+		})
+	}
+}
+
+func TestVolumeSpecDifferences(t *testing.T) {
+	volume := &msa.Volume{Name: "vol01", PoolName: "B", Size: "10.0GB", SizeNumeric: "19531250", Properties: map[string]string{"blocksize": "512"}}
+
+	if diffs := volumeSpecDifferences(volume, []string{"A", "B"}, "10GB"); len(diffs) != 0 {
+		t.Fatalf("expected a volume in a fallback pool to match, got %v", diffs)
+	}
+	diffs := volumeSpecDifferences(volume, []string{"A"}, "20GB")
+	if len(diffs) != 2 || !strings.Contains(diffs[0], `pool is "B"`) || !strings.Contains(diffs[1], `want "20GB"`) {
+		t.Fatalf("expected pool and size differences, got %v", diffs)
+	}
+}
+
+func TestHostGroupCreateReconcilesExistingGroup(t *testing.T) {
+	server, paths := newMSATestServer(t, func(path string) string {
+		if path == "/api/show/host-groups" {
+			return `<RESPONSE VERSION="L100"><OBJECT basetype="host-group" name="host-group"><PROPERTY name="name">Group1</PROPERTY><PROPERTY name="serial-number">SN-G1</PROPERTY>` +
+				`<OBJECT basetype="host" name="host"><PROPERTY name="name">HostA</PROPERTY></OBJECT>` +
+				`<OBJECT basetype="host" name="host"><PROPERTY name="name">HostB</PROPERTY></OBJECT>` +
+				`</OBJECT></RESPONSE>`
+		}
+		return `<RESPONSE VERSION="L100"></RESPONSE>`
+	})
+	client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	r := &hostGroupResource{client: client}
+
+	create := func(adopt bool, hosts ...string) resource.CreateResponse {
+		values := make([]tftypes.Value, 0, len(hosts))
+		for _, host := range hosts {
+			values = append(values, tftypes.NewValue(tftypes.String, host))
+		}
+		planned := resourceState(t, r, map[string]tftypes.Value{
+			"name":           tftypes.NewValue(tftypes.String, "Group1"),
+			"hosts":          tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, values),
+			"adopt_existing": tftypes.NewValue(tftypes.Bool, adopt),
+		})
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: planned.Schema}}
+		r.Create(context.Background(), resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, &resp)
+		return resp
+	}
+
+	t.Run("adopts a matching group", func(t *testing.T) {
+		resp := create(true, "hostb", "HostA")
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("expected the group to be adopted, got %v", resp.Diagnostics)
+		}
+		var got hostGroupResourceModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &got)...)
+		if got.ID.ValueString() != "SN-G1" {
+			t.Fatalf("expected adopted state for SN-G1, got %+v", got)
+		}
+	})
+
+	t.Run("requires adopt_existing", func(t *testing.T) {
+		resp := create(false, "HostA", "HostB")
+		if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "adopt_existing = true") {
+			t.Fatalf("expected a conflict naming adopt_existing, got %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("reports differing members", func(t *testing.T) {
+		resp := create(true, "HostA", "HostC")
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected a conflict")
+		}
+		detail := resp.Diagnostics.Errors()[0].Detail()
+		if !strings.Contains(detail, "hosts HostC are not members") || !strings.Contains(detail, "hosts HostB are unexpected members") {
+			t.Fatalf("unexpected conflict detail %q", detail)
+		}
+	})
+
+	for _, path := range *paths {
+		if strings.HasPrefix(path, "/api/create/") {
+			t.Fatalf("expected no create command, got %v", *paths)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// checkAllowDestroy blocks the delete of a resource whose allow_destroy is
+// not true. Delete calls it before resolving the client, so a blocked
+// destroy makes no array requests.
+func checkAllowDestroy(allowDestroy types.Bool, resourceType, name string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !allowDestroy.IsNull() && !allowDestroy.IsUnknown() && allowDestroy.ValueBool() {
+		return diags
+	}
+
+	label := resourceType
+	if name = strings.TrimSpace(name); name != "" {
+		label = fmt.Sprintf("%s %q", resourceType, name)
+	}
+	diags.AddError(
+		"Deletion blocked by allow_destroy",
+		fmt.Sprintf("%s has allow_destroy = false, so the provider refused to delete it and sent no commands to the array. "+
+			"Set allow_destroy = true on this resource and run `terraform apply` before destroying it; removing the resource from the configuration keeps the false value recorded in state. "+
+			"allow_destroy is separate from lifecycle { prevent_destroy }: prevent_destroy stops Terraform from planning the destroy, while allow_destroy is checked by the provider when the destroy runs, so both must permit it.",
+			label),
+	)
+	return diags
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCheckAllowDestroy(t *testing.T) {
+	if diags := checkAllowDestroy(types.BoolValue(true), "hpe_msa_volume", "vol01"); diags.HasError() {
+		t.Fatalf("expected allow_destroy = true to permit deletion, got %v", diags)
+	}
+
+	for name, value := range map[string]types.Bool{
+		"false":   types.BoolValue(false),
+		"null":    types.BoolNull(),
+		"unknown": types.BoolUnknown(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			diags := checkAllowDestroy(value, "hpe_msa_volume", "vol01")
+			if !diags.HasError() {
+				t.Fatalf("expected deletion to be blocked")
+			}
+			detail := diags.Errors()[0].Detail()
+			for _, want := range []string{`hpe_msa_volume "vol01"`, "Set allow_destroy = true", "prevent_destroy"} {
+				if !strings.Contains(detail, want) {
+					t.Fatalf("expected %q in detail, got %s", want, detail)
+				}
+			}
+		})
+	}
+}
+
+func TestBlockedDestroyMakesNoArrayRequests(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(`<RESPONSE VERSION="L100"></RESPONSE>`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		resource resource.Resource
+		values   map[string]tftypes.Value
+	}{
+		{name: "volume", resource: &volumeResource{client: client}, values: map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "vol01"), "id": tftypes.NewValue(tftypes.String, "SN1")}},
+		{name: "snapshot", resource: &snapshotResource{client: client}, values: map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "snap01")}},
+		{name: "clone", resource: &cloneResource{client: client}, values: map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "clone01")}},
+		{name: "host", resource: &hostResource{client: client}, values: map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "host01")}},
+		{name: "host group", resource: &hostGroupResource{client: client}, values: map[string]tftypes.Value{"name": tftypes.NewValue(tftypes.String, "group01")}},
+		{name: "initiator", resource: &initiatorResource{client: client}, values: map[string]tftypes.Value{"initiator_id": tftypes.NewValue(tftypes.String, "iqn.1991-05.com.example:host-a")}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.values["allow_destroy"] = tftypes.NewValue(tftypes.Bool, false)
+			state := resourceState(t, tc.resource, tc.values)
+
+			resp := resource.DeleteResponse{State: state}
+			tc.resource.Delete(context.Background(), resource.DeleteRequest{State: state}, &resp)
+			if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Deletion blocked by allow_destroy" {
+				t.Fatalf("expected deletion to be blocked, got %v", resp.Diagnostics)
+			}
+			if got := requests.Load(); got != 0 {
+				t.Fatalf("expected no array requests for a blocked destroy, got %d", got)
+			}
+		})
+	}
+
+	// Control: a permitted destroy reaches the array.
+	r := &volumeResource{client: client}
+	state := resourceState(t, r, map[string]tftypes.Value{
+		"name":          tftypes.NewValue(tftypes.String, "vol01"),
+		"id":            tftypes.NewValue(tftypes.String, "SN1"),
+		"allow_destroy": tftypes.NewValue(tftypes.Bool, true),
+	})
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, &resource.DeleteResponse{State: state})
+	if requests.Load() == 0 {
+		t.Fatalf("expected a permitted destroy to reach the array")
+	}
+}
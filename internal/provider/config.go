@@ -49,3 +49,36 @@ func boolOrEnv(value types.Bool, env string) (bool, diag.Diagnostics) {
 
 	return parsed, diags
 }
+
+// listOrEnv returns the configured string list, or the comma-separated
+// entries of env when the attribute is null.
+func listOrEnv(value types.List, env string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value.IsUnknown() {
+		diags.AddError("Invalid configuration", env+" is unknown")
+		return nil, diags
+	}
+
+	var raw []string
+	if !value.IsNull() {
+		for _, element := range value.Elements() {
+			item, ok := element.(types.String)
+			if !ok || item.IsUnknown() {
+				diags.AddError("Invalid configuration", env+" contains an unknown value")
+				return nil, diags
+			}
+			raw = append(raw, item.ValueString())
+		}
+	} else if envValue := strings.TrimSpace(os.Getenv(env)); envValue != "" {
+		raw = strings.Split(envValue, ",")
+	}
+
+	values := make([]string, 0, len(raw))
+	for _, item := range raw {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values, diags
+}
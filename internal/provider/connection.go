@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// connectionModel is the optional per-resource `connection` block that points
+// a resource at a different array than the provider endpoint.
+type connectionModel struct {
+	Endpoint    types.String `tfsdk:"endpoint"`
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	InsecureTLS types.Bool   `tfsdk:"insecure_tls"`
+}
+
+func connectionBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "Optional override to manage this object on a different array than the provider endpoint. Unset credentials and insecure_tls inherit the provider settings.",
+		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				Description: "MSA API endpoint for this resource (e.g., https://msa2.example.com).",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "Username for the override endpoint.",
+				Optional:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "Password for the override endpoint.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"insecure_tls": schema.BoolAttribute{
+				Description: "Skip TLS verification for the override endpoint.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// clientFor returns the client a resource should use: a client for its
+// connection block when one is configured, otherwise the provider client.
+func clientFor(providerClient *msa.Client, conn *connectionModel) (*msa.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if conn == nil || conn.Endpoint.IsNull() {
+		return providerClient, diags
+	}
+	if providerClient == nil {
+		diags.AddError("Provider not configured", "Missing MSA client")
+		return nil, diags
+	}
+	if conn.Endpoint.IsUnknown() || conn.Username.IsUnknown() || conn.Password.IsUnknown() || conn.InsecureTLS.IsUnknown() {
+		diags.AddError("Invalid connection", "connection settings must be known before the resource can be managed")
+		return nil, diags
+	}
+	endpoint := strings.TrimSpace(conn.Endpoint.ValueString())
+	if endpoint == "" {
+		diags.AddError("Invalid connection", "connection.endpoint must not be empty")
+		return nil, diags
+	}
+
+	override := msa.ConnectionOverride{
+		Endpoint: endpoint,
+		Username: conn.Username.ValueString(),
+		Password: conn.Password.ValueString(),
+	}
+	if !conn.InsecureTLS.IsNull() {
+		insecure := conn.InsecureTLS.ValueBool()
+		override.InsecureTLS = &insecure
+	}
+
+	client, err := providerClient.WithConnection(override)
+	if err != nil {
+		diags.AddError("Invalid connection", err.Error())
+		return nil, diags
+	}
+	return client, diags
+}
+
+// applyConnection swaps *client for the connection override, if any, so the
+// resource's helpers all talk to the same array for the operation.
+func applyConnection(client **msa.Client, conn *connectionModel) diag.Diagnostics {
+	resolved, diags := clientFor(*client, conn)
+	if diags.HasError() {
+		return diags
+	}
+	*client = resolved
+	return diags
+}
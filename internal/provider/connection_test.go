@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// resourceState builds a State from the resource schema, leaving every
+// attribute or block not present in values null.
+func resourceState(t *testing.T, r resource.Resource, values map[string]tftypes.Value) tfsdk.State {
+	t.Helper()
+
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("resource schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("resource schema is not an object type")
+	}
+
+	attrs := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attrs[name] = value
+			continue
+		}
+		attrs[name] = tftypes.NewValue(attrType, nil)
+	}
+
+	return tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, attrs),
+	}
+}
+
+func newMSATestServer(t *testing.T, handle func(path string) string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var paths []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		if strings.HasPrefix(r.URL.Path, "/api/login/") {
+			_, _ = w.Write([]byte(`<RESPONSE VERSION="L100"><OBJECT basetype="status" name="status"><PROPERTY name="response-type">Success</PROPERTY><PROPERTY name="response-type-numeric">0</PROPERTY><PROPERTY name="response">session-1</PROPERTY><PROPERTY name="return-code">1</PROPERTY></OBJECT></RESPONSE>`))
+			return
+		}
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(handle(r.URL.Path)))
+	}))
+	t.Cleanup(server.Close)
+	return server, &paths
+}
+
+func TestVolumeReadUsesConnectionOverride(t *testing.T) {
+	primary, primaryPaths := newMSATestServer(t, func(string) string {
+		return `<RESPONSE VERSION="L100"></RESPONSE>`
+	})
+	override, overridePaths := newMSATestServer(t, func(path string) string {
+		if path != "/api/show/volumes" {
+			return `<RESPONSE VERSION="L100"></RESPONSE>`
+		}
+		return `<RESPONSE VERSION="L100"><OBJECT basetype="volumes" name="volume"><PROPERTY name="volume-name">vol-b</PROPERTY><PROPERTY name="serial-number">SN-B</PROPERTY><PROPERTY name="storage-pool-name">B</PROPERTY></OBJECT></RESPONSE>`
+	})
+
+	providerClient, err := msa.NewClient(msa.Config{
+		Endpoint:    primary.URL,
+		Username:    "user",
+		Password:    "pass",
+		InsecureTLS: true,
+	})
+	if err != nil {
+		t.Fatalf("create provider client: %v", err)
+	}
+
+	r := &volumeResource{client: providerClient}
+	connectionType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"endpoint":     tftypes.String,
+		"username":     tftypes.String,
+		"password":     tftypes.String,
+		"insecure_tls": tftypes.Bool,
+	}}
+	state := resourceState(t, r, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "SN-B"),
+		"name": tftypes.NewValue(tftypes.String, "vol-b"),
+		"size": tftypes.NewValue(tftypes.String, "10GB"),
+		"connection": tftypes.NewValue(connectionType, map[string]tftypes.Value{
+			"endpoint":     tftypes.NewValue(tftypes.String, override.URL),
+			"username":     tftypes.NewValue(tftypes.String, nil),
+			"password":     tftypes.NewValue(tftypes.String, nil),
+			"insecure_tls": tftypes.NewValue(tftypes.Bool, nil),
+		}),
+	})
+
+	resp := resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got volumeResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &got)...)
+	if got.Pool.ValueString() != "B" {
+		t.Fatalf("expected volume from override array, got pool %q", got.Pool.ValueString())
+	}
+	if len(*primaryPaths) != 0 {
+		t.Fatalf("expected no commands against the provider endpoint, got %v", *primaryPaths)
+	}
+	if len(*overridePaths) != 1 || (*overridePaths)[0] != "/api/show/volumes" {
+		t.Fatalf("unexpected override commands %v", *overridePaths)
+	}
+}
+
+func TestClientForFallsBackToProviderClient(t *testing.T) {
+	providerClient, err := msa.NewClient(msa.Config{Endpoint: "https://msa.example", Username: "user", Password: "pass"})
+	if err != nil {
+		t.Fatalf("create provider client: %v", err)
+	}
+
+	client, diags := clientFor(providerClient, nil)
+	if diags.HasError() || client != providerClient {
+		t.Fatalf("expected provider client without a connection block")
+	}
+
+	client, diags = clientFor(providerClient, &connectionModel{Endpoint: types.StringNull()})
+	if diags.HasError() || client != providerClient {
+		t.Fatalf("expected provider client when endpoint is unset")
+	}
+
+	_, diags = clientFor(providerClient, &connectionModel{Endpoint: types.StringValue("  ")})
+	if !diags.HasError() {
+		t.Fatalf("expected error for empty endpoint")
+	}
+}
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const defaultMaxClockSkewSeconds = 60
+
+var _ datasource.DataSource = (*arrayTimeDataSource)(nil)
+
+func NewArrayTimeDataSource() datasource.DataSource {
+	return &arrayTimeDataSource{}
+}
+
+type arrayTimeDataSource struct {
+	client *msa.Client
+}
+
+type arrayTimeDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	MaxSkewSeconds types.Int64  `tfsdk:"max_skew_seconds"`
+	ArrayTime      types.String `tfsdk:"array_time"`
+	HostTime       types.String `tfsdk:"host_time"`
+	SkewSeconds    types.Int64  `tfsdk:"skew_seconds"`
+	TimeZoneOffset types.String `tfsdk:"time_zone_offset"`
+	NTPState       types.String `tfsdk:"ntp_state"`
+	NTPServer      types.String `tfsdk:"ntp_server"`
+	Properties     types.Map    `tfsdk:"properties"`
+}
+
+func (d *arrayTimeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_msa_array_time"
+}
+
+func (d *arrayTimeDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports the array clock from `show controller-date` and its skew against the machine running Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier.",
+				Computed:    true,
+			},
+			"max_skew_seconds": schema.Int64Attribute{
+				Description: "Warn when the absolute skew exceeds this many seconds. Defaults to 60.",
+				Optional:    true,
+			},
+			"array_time": schema.StringAttribute{
+				Description: "Array time in RFC 3339 format, in the array's time zone.",
+				Computed:    true,
+			},
+			"host_time": schema.StringAttribute{
+				Description: "Local time (UTC, RFC 3339) the array time was compared against, taken halfway through the request.",
+				Computed:    true,
+			},
+			"skew_seconds": schema.Int64Attribute{
+				Description: "Seconds the array clock is ahead of the local clock (negative when behind).",
+				Computed:    true,
+			},
+			"time_zone_offset": schema.StringAttribute{
+				Description: "Time zone offset configured on the array.",
+				Computed:    true,
+			},
+			"ntp_state": schema.StringAttribute{
+				Description: "Whether the array synchronizes its clock with NTP.",
+				Computed:    true,
+			},
+			"ntp_server": schema.StringAttribute{
+				Description: "NTP server address configured on the array.",
+				Computed:    true,
+			},
+			"properties": schema.MapAttribute{
+				Description: "Raw properties returned by the XML API.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *arrayTimeDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*msa.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type", "Expected *msa.Client")
+		return
+	}
+
+	d.client = client
+}
+
+func (d *arrayTimeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data arrayTimeDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
+		return
+	}
+
+	maxSkew := int64(defaultMaxClockSkewSeconds)
+	if !data.MaxSkewSeconds.IsNull() && !data.MaxSkewSeconds.IsUnknown() {
+		maxSkew = data.MaxSkewSeconds.ValueInt64()
+		if maxSkew < 0 {
+			resp.Diagnostics.AddError("Invalid max_skew_seconds", "max_skew_seconds must not be negative")
+			return
+		}
+	}
+
+	date, hostTime, err := readArrayTime(ctx, d.client, time.Now)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read array time", err.Error())
+		return
+	}
+
+	skew := clockSkewSeconds(date, hostTime)
+	if summary, detail, ok := clockSkewWarning(skew, maxSkew, date.NTPState); ok {
+		resp.Diagnostics.AddWarning(summary, detail)
+	}
+
+	props, diags := types.MapValueFrom(ctx, types.StringType, date.Properties)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue("array-time")
+	data.ArrayTime = types.StringValue(date.Time.Format(time.RFC3339))
+	data.HostTime = types.StringValue(hostTime.UTC().Format(time.RFC3339))
+	data.SkewSeconds = types.Int64Value(skew)
+	data.TimeZoneOffset = stringValueOrNull(date.TimeZoneOffset)
+	data.NTPState = stringValueOrNull(date.NTPState)
+	data.NTPServer = stringValueOrNull(date.NTPServer)
+	data.Properties = props
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readArrayTime queries the array clock and returns the local time halfway
+// through the request so network latency does not count as skew. Firmware
+// without `show controller-date` is asked for `show system` instead.
+func readArrayTime(ctx context.Context, client commandExecutor, now func() time.Time) (msa.ControllerDate, time.Time, error) {
+	var errs []string
+	for _, parts := range [][]string{{"show", "controller-date"}, {"show", "system"}} {
+		started := now()
+		response, err := client.Execute(ctx, parts...)
+		finished := now()
+		if err == nil {
+			var date msa.ControllerDate
+			date, err = msa.ControllerDateFromResponse(response)
+			if err == nil {
+				return date, started.Add(finished.Sub(started) / 2), nil
+			}
+		}
+		if ctx.Err() != nil {
+			return msa.ControllerDate{}, time.Time{}, err
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", strings.Join(parts, " "), err))
+	}
+	return msa.ControllerDate{}, time.Time{}, errors.New(strings.Join(errs, "; "))
+}
+
+func clockSkewSeconds(date msa.ControllerDate, hostTime time.Time) int64 {
+	return int64(date.ClockSkew(hostTime).Round(time.Second) / time.Second)
+}
+
+func clockSkewWarning(skew, maxSkew int64, ntpState string) (string, string, bool) {
+	magnitude := skew
+	if magnitude < 0 {
+		magnitude = -magnitude
+	}
+	if magnitude <= maxSkew {
+		return "", "", false
+	}
+
+	direction := "ahead of"
+	if skew < 0 {
+		direction = "behind"
+	}
+	detail := fmt.Sprintf("The array clock is %ds %s this machine's clock (threshold %ds). Snapshot schedules and timestamps on the array will not line up with local time.", magnitude, direction, maxSkew)
+	if ntpState != "" && !strings.EqualFold(strings.TrimSpace(ntpState), "enabled") {
+		detail += " NTP is not enabled on the array; configure it with `set controller-date ntp enabled ntpaddress <server>`."
+	}
+	return "Array clock skew", detail, true
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+)
+
+func TestReadArrayTimeSkew(t *testing.T) {
+	client := fakeVolumeDeleteProbeClient{
+		results: map[string]fakeVolumeDeleteProbeResult{
+			"show controller-date": {
+				response: msa.Response{
+					Objects: []msa.Object{
+						{
+							BaseType: "time-settings-table",
+							Properties: []msa.Property{
+								{Name: "date-time", Value: "2026-03-15 10:25:00"},
+								{Name: "time-zone-offset", Value: "+01:00"},
+								{Name: "ntp-state", Value: "Disabled"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// The request is bracketed by 09:22:00 and 09:22:02 UTC, so the host
+	// reference is 09:22:01 and the array is 179s ahead.
+	clock := []time.Time{
+		time.Date(2026, 3, 15, 9, 22, 0, 0, time.UTC),
+		time.Date(2026, 3, 15, 9, 22, 2, 0, time.UTC),
+	}
+	now := func() time.Time {
+		next := clock[0]
+		clock = clock[1:]
+		return next
+	}
+
+	date, hostTime, err := readArrayTime(context.Background(), client, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hostTime.Equal(time.Date(2026, 3, 15, 9, 22, 1, 0, time.UTC)) {
+		t.Fatalf("unexpected host reference %s", hostTime)
+	}
+	skew := clockSkewSeconds(date, hostTime)
+	if skew != 179 {
+		t.Fatalf("expected 179s skew, got %d", skew)
+	}
+
+	summary, detail, ok := clockSkewWarning(skew, defaultMaxClockSkewSeconds, date.NTPState)
+	if !ok || summary != "Array clock skew" {
+		t.Fatalf("expected skew warning")
+	}
+	if !strings.Contains(detail, "179s ahead of") || !strings.Contains(detail, "NTP is not enabled") {
+		t.Fatalf("unexpected warning detail %q", detail)
+	}
+}
+
+func TestClockSkewWarningThreshold(t *testing.T) {
+	if _, _, ok := clockSkewWarning(-60, 60, "Enabled"); ok {
+		t.Fatalf("expected no warning at the threshold")
+	}
+	_, detail, ok := clockSkewWarning(-61, 60, "Enabled")
+	if !ok || !strings.Contains(detail, "61s behind") {
+		t.Fatalf("expected behind warning, got %q", detail)
+	}
+	if strings.Contains(detail, "NTP") {
+		t.Fatalf("expected no NTP hint when NTP is enabled, got %q", detail)
+	}
+}
+
+func TestReadArrayTimeFallsBackToShowSystem(t *testing.T) {
+	client := fakeVolumeDeleteProbeClient{
+		results: map[string]fakeVolumeDeleteProbeResult{
+			"show system": {
+				response: msa.Response{
+					Objects: []msa.Object{
+						{
+							BaseType: "system",
+							Properties: []msa.Property{
+								{Name: "system-name", Value: "msa01"},
+								{Name: "date-time-numeric", Value: "1773566553"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	date, _, err := readArrayTime(context.Background(), client, time.Now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !date.Time.Equal(time.Date(2026, 3, 15, 9, 22, 33, 0, time.UTC)) {
+		t.Fatalf("unexpected array time %s", date.Time)
+	}
+}
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = (*controllerCacheDataSource)(nil)
+
+func NewControllerCacheDataSource() datasource.DataSource {
+	return &controllerCacheDataSource{}
+}
+
+type controllerCacheDataSource struct {
+	client *msa.Client
+}
+
+type controllerCacheDataSourceModel struct {
+	ID          types.String           `tfsdk:"id"`
+	Controllers []controllerCacheModel `tfsdk:"controllers"`
+}
+
+type controllerCacheModel struct {
+	ControllerID       types.String `tfsdk:"controller_id"`
+	CacheMemorySize    types.String `tfsdk:"cache_memory_size"`
+	WriteBackStatus    types.String `tfsdk:"write_back_status"`
+	CacheBackupStatus  types.String `tfsdk:"cache_backup_status"`
+	CompactFlashStatus types.String `tfsdk:"compact_flash_status"`
+	CompactFlashHealth types.String `tfsdk:"compact_flash_health"`
+	BackupDegraded     types.Bool   `tfsdk:"backup_degraded"`
+}
+
+func (d *controllerCacheDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_msa_controller_cache"
+}
+
+func (d *controllerCacheDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Per-controller cache state and cache backup health from `show controllers` and `show cache-parameters`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier.",
+				Computed:    true,
+			},
+			"controllers": schema.ListNestedAttribute{
+				Description: "Controllers, sorted by controller ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"controller_id": schema.StringAttribute{
+							Description: "Controller ID (e.g. `A`, `B`).",
+							Computed:    true,
+						},
+						"cache_memory_size": schema.StringAttribute{
+							Description: "Cache memory size in MB as reported by the array.",
+							Computed:    true,
+						},
+						"write_back_status": schema.StringAttribute{
+							Description: "Write-back cache status (e.g. `Enabled`, `Disabled`).",
+							Computed:    true,
+						},
+						"cache_backup_status": schema.StringAttribute{
+							Description: "Cache backup (supercapacitor) status.",
+							Computed:    true,
+						},
+						"compact_flash_status": schema.StringAttribute{
+							Description: "Cache backup compact flash status (e.g. `Installed`).",
+							Computed:    true,
+						},
+						"compact_flash_health": schema.StringAttribute{
+							Description: "Cache backup compact flash health.",
+							Computed:    true,
+						},
+						"backup_degraded": schema.BoolAttribute{
+							Description: "Whether the cache backup status or compact flash health is not OK, in which case the array forces write-through caching.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *controllerCacheDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*msa.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type", "Expected *msa.Client")
+		return
+	}
+
+	d.client = client
+}
+
+func (d *controllerCacheDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data controllerCacheDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
+		return
+	}
+
+	caches, err := readControllerCaches(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to query controller cache", err.Error())
+		return
+	}
+	appendCacheBackupWarnings(&resp.Diagnostics, caches)
+
+	data.Controllers = controllerCacheModels(caches)
+	data.ID = types.StringValue("controller_cache")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readControllerCaches merges `show controllers` with `show cache-parameters`.
+// Firmware without `show cache-parameters` only reports the controller view.
+func readControllerCaches(ctx context.Context, client commandExecutor) ([]msa.ControllerCache, error) {
+	controllers, err := client.Execute(ctx, "show", "controllers")
+	if err != nil {
+		return nil, fmt.Errorf("show controllers: %w", err)
+	}
+
+	parameters, err := client.Execute(ctx, "show", "cache-parameters")
+	if err != nil {
+		if isUnsupportedUsageProbeError(err) {
+			tflog.Debug(ctx, "show cache-parameters not supported; reporting controller cache only", map[string]any{
+				"error": err.Error(),
+			})
+			return msa.ControllerCachesFromResponses(controllers), nil
+		}
+		return nil, fmt.Errorf("show cache-parameters: %w", err)
+	}
+	return msa.ControllerCachesFromResponses(controllers, parameters), nil
+}
+
+// appendCacheBackupWarnings warns for each controller whose cache backup is
+// degraded, since the resulting write-through fallback explains sudden drops
+// in write performance.
+func appendCacheBackupWarnings(diags *diag.Diagnostics, caches []msa.ControllerCache) {
+	for _, cache := range caches {
+		if !cache.BackupDegraded() {
+			continue
+		}
+		states := make([]string, 0, 2)
+		if cache.CacheBackupStatus != "" {
+			states = append(states, fmt.Sprintf("cache backup status %q", cache.CacheBackupStatus))
+		}
+		if cache.CompactFlashHealth != "" {
+			states = append(states, fmt.Sprintf("compact flash health %q", cache.CompactFlashHealth))
+		}
+		diags.AddWarning(
+			"Controller cache backup degraded",
+			fmt.Sprintf("Controller %s reports %s. While the cache backup is degraded the array forces write-through caching, which lowers write performance. Write-back status: %s.",
+				cache.ControllerID, strings.Join(states, " and "), firstNonEmpty(cache.WriteBackStatus, "not reported")),
+		)
+	}
+}
+
+func controllerCacheModels(caches []msa.ControllerCache) []controllerCacheModel {
+	models := make([]controllerCacheModel, 0, len(caches))
+	for _, cache := range caches {
+		models = append(models, controllerCacheModel{
+			ControllerID:       types.StringValue(cache.ControllerID),
+			CacheMemorySize:    stringValueOrNull(cache.CacheMemorySize),
+			WriteBackStatus:    stringValueOrNull(cache.WriteBackStatus),
+			CacheBackupStatus:  stringValueOrNull(cache.CacheBackupStatus),
+			CompactFlashStatus: stringValueOrNull(cache.CompactFlashStatus),
+			CompactFlashHealth: stringValueOrNull(cache.CompactFlashHealth),
+			BackupDegraded:     types.BoolValue(cache.BackupDegraded()),
+		})
+	}
+	return models
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestReadControllerCachesMergesCacheParameters(t *testing.T) {
+	object := func(baseType string, props ...string) msa.Object {
+		obj := msa.Object{BaseType: baseType}
+		for i := 0; i+1 < len(props); i += 2 {
+			obj.Properties = append(obj.Properties, msa.Property{Name: props[i], Value: props[i+1]})
+		}
+		return obj
+	}
+	client := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show controllers": {response: msa.Response{Objects: []msa.Object{
+			object("controllers", "controller-id", "A", "cache-memory-size", "6144"),
+			object("controllers", "controller-id", "B", "cache-memory-size", "6144"),
+		}}},
+		"show cache-parameters": {response: msa.Response{Objects: []msa.Object{
+			object("controller-cache-parameters", "controller-id", "A", "write-back-status", "Enabled", "cache-backup-status", "OK"),
+			object("controller-cache-parameters", "controller-id", "B", "write-back-status", "Disabled", "cache-backup-status", "Failed"),
+		}}},
+	}}
+
+	caches, err := readControllerCaches(context.Background(), client)
+	if err != nil {
+		t.Fatalf("read controller caches: %v", err)
+	}
+	models := controllerCacheModels(caches)
+	if len(models) != 2 {
+		t.Fatalf("expected 2 controllers, got %d", len(models))
+	}
+	if models[0].CacheMemorySize.ValueString() != "6144" || models[0].BackupDegraded.ValueBool() {
+		t.Fatalf("unexpected controller A model %+v", models[0])
+	}
+	if models[1].WriteBackStatus.ValueString() != "Disabled" || !models[1].BackupDegraded.ValueBool() {
+		t.Fatalf("unexpected controller B model %+v", models[1])
+	}
+	if !models[0].CompactFlashHealth.IsNull() {
+		t.Fatalf("expected null compact flash health when unreported, got %v", models[0].CompactFlashHealth)
+	}
+
+	var diags diag.Diagnostics
+	appendCacheBackupWarnings(&diags, caches)
+	if diags.WarningsCount() != 1 {
+		t.Fatalf("expected one warning for controller B, got %v", diags)
+	}
+	detail := diags.Warnings()[0].Detail()
+	if !strings.Contains(detail, "Controller B") || !strings.Contains(detail, `cache backup status "Failed"`) || !strings.Contains(detail, "write-through") {
+		t.Fatalf("unexpected warning detail: %s", detail)
+	}
+}
+
+func TestReadControllerCachesWithoutCacheParameters(t *testing.T) {
+	client := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show controllers": {response: msa.Response{Objects: []msa.Object{{
+			BaseType:   "controllers",
+			Properties: []msa.Property{{Name: "controller-id", Value: "A"}, {Name: "cache-memory-size", Value: "4096"}},
+		}}}},
+	}}
+
+	caches, err := readControllerCaches(context.Background(), client)
+	if err != nil {
+		t.Fatalf("expected unsupported show cache-parameters to be skipped, got %v", err)
+	}
+	if len(caches) != 1 || caches[0].CacheMemorySize != "4096" {
+		t.Fatalf("unexpected caches %+v", caches)
+	}
+}
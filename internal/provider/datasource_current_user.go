@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = (*currentUserDataSource)(nil)
+
+func NewCurrentUserDataSource() datasource.DataSource {
+	return &currentUserDataSource{}
+}
+
+type currentUserDataSource struct {
+	client *msa.Client
+}
+
+type currentUserDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Username   types.String `tfsdk:"username"`
+	Roles      types.List   `tfsdk:"roles"`
+	Interfaces types.List   `tfsdk:"interfaces"`
+	UserType   types.String `tfsdk:"user_type"`
+	Locale     types.String `tfsdk:"locale"`
+	CanManage  types.Bool   `tfsdk:"can_manage"`
+	Properties types.Map    `tfsdk:"properties"`
+}
+
+func (d *currentUserDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_msa_current_user"
+}
+
+func (d *currentUserDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reports the roles and interfaces of the user the provider is configured with.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "User identifier (the username).",
+				Computed:    true,
+			},
+			"username": schema.StringAttribute{
+				Description: "Configured username.",
+				Computed:    true,
+			},
+			"roles": schema.ListAttribute{
+				Description: "Roles assigned to the user (e.g. monitor, manage).",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"interfaces": schema.ListAttribute{
+				Description: "Management interfaces the user may access (e.g. cli, wbi).",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"user_type": schema.StringAttribute{
+				Description: "User type reported by the array.",
+				Computed:    true,
+			},
+			"locale": schema.StringAttribute{
+				Description: "User locale reported by the array.",
+				Computed:    true,
+			},
+			"can_manage": schema.BoolAttribute{
+				Description: "Whether the user holds the manage role required for create/update/delete operations.",
+				Computed:    true,
+			},
+			"properties": schema.MapAttribute{
+				Description: "Raw properties returned by the XML API.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *currentUserDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*msa.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type", "Expected *msa.Client")
+		return
+	}
+
+	d.client = client
+}
+
+func (d *currentUserDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
+		return
+	}
+
+	username := d.client.Username()
+	response, err := d.client.Execute(ctx, "show", "users")
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to query users", err.Error())
+		return
+	}
+
+	user, ok := selectCurrentUser(msa.UsersFromResponse(response), username)
+	if !ok {
+		resp.Diagnostics.AddError("Current user not found", fmt.Sprintf("The array did not report user %q in show users output", username))
+		return
+	}
+
+	var data currentUserDataSourceModel
+	roles, diags := types.ListValueFrom(ctx, types.StringType, user.Roles)
+	resp.Diagnostics.Append(diags...)
+	interfaces, diags := types.ListValueFrom(ctx, types.StringType, user.Interfaces)
+	resp.Diagnostics.Append(diags...)
+	props, diags := types.MapValueFrom(ctx, types.StringType, user.Properties)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(user.Name)
+	data.Username = types.StringValue(user.Name)
+	data.Roles = roles
+	data.Interfaces = interfaces
+	data.UserType = stringValueOrNull(user.UserType)
+	data.Locale = stringValueOrNull(user.Locale)
+	data.CanManage = types.BoolValue(user.HasRole("manage"))
+	data.Properties = props
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func selectCurrentUser(users []msa.User, username string) (msa.User, bool) {
+	username = strings.TrimSpace(username)
+	for _, user := range users {
+		if strings.EqualFold(user.Name, username) {
+			return user, true
+		}
+	}
+	return msa.User{}, false
+}
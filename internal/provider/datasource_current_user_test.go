@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+)
+
+func TestSelectCurrentUser(t *testing.T) {
+	users := []msa.User{
+		{Name: "monitor", Roles: []string{"monitor"}},
+		{Name: "Manage", Roles: []string{"manage", "monitor"}},
+	}
+
+	user, ok := selectCurrentUser(users, " manage ")
+	if !ok {
+		t.Fatalf("expected user to be found")
+	}
+	if user.Name != "Manage" || !user.HasRole("manage") {
+		t.Fatalf("unexpected user %+v", user)
+	}
+
+	if _, ok := selectCurrentUser(users, "other"); ok {
+		t.Fatalf("expected unknown user to be missing")
+	}
+}
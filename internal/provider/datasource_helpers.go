@@ -1,13 +1,21 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// commandExecutor is the subset of *msa.Client used by helpers that only
+// issue commands, so they can be exercised with fakes.
+type commandExecutor interface {
+	Execute(ctx context.Context, parts ...string) (msa.Response, error)
+}
+
 func findObjectByName(response msa.Response, name string, keys []string, entity string) (msa.Object, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	original := strings.TrimSpace(name)
@@ -54,6 +62,20 @@ func firstNonEmpty(values ...string) string {
 	return ""
 }
 
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+func int64ValueOrNull(value int64, ok bool) types.Int64 {
+	if !ok {
+		return types.Int64Null()
+	}
+	return types.Int64Value(value)
+}
+
 func title(value string) string {
 	value = strings.TrimSpace(value)
 	if value == "" {
@@ -0,0 +1,193 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ datasource.DataSource = (*inventoryDataSource)(nil)
+
+func NewInventoryDataSource() datasource.DataSource {
+	return &inventoryDataSource{}
+}
+
+type inventoryDataSource struct {
+	client *msa.Client
+}
+
+type inventoryDataSourceModel struct {
+	ID          types.String         `tfsdk:"id"`
+	IncludeFRUs types.Bool           `tfsdk:"include_frus"`
+	Count       types.Int64          `tfsdk:"count"`
+	Items       []inventoryItemModel `tfsdk:"items"`
+}
+
+type inventoryItemModel struct {
+	ID           types.String `tfsdk:"id"`
+	Type         types.String `tfsdk:"type"`
+	EnclosureID  types.String `tfsdk:"enclosure_id"`
+	Name         types.String `tfsdk:"name"`
+	Status       types.String `tfsdk:"status"`
+	Model        types.String `tfsdk:"model"`
+	SerialNumber types.String `tfsdk:"serial_number"`
+	PartNumber   types.String `tfsdk:"part_number"`
+}
+
+func (d *inventoryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_msa_inventory"
+}
+
+func (d *inventoryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Enclosure, power supply, fan and FRU inventory from `show enclosures` and `show frus`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier.",
+				Computed:    true,
+			},
+			"include_frus": schema.BoolAttribute{
+				Description: "Also query `show frus`. Defaults to true; set false to keep the query to `show enclosures`.",
+				Optional:    true,
+			},
+			"count": schema.Int64Attribute{
+				Description: "Number of inventory items returned.",
+				Computed:    true,
+			},
+			"items": schema.ListNestedAttribute{
+				Description: "Inventory items, sorted by type and ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Durable ID, or enclosure/slot for FRUs (e.g. `psu_0.0`, `0/MID-PLANE SLOT`).",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Item type: enclosure, power-supply, fan, controller or fru.",
+							Computed:    true,
+						},
+						"enclosure_id": schema.StringAttribute{
+							Description: "Enclosure the item belongs to.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name reported by the array.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status reported by the array.",
+							Computed:    true,
+						},
+						"model": schema.StringAttribute{
+							Description: "Model or FRU description.",
+							Computed:    true,
+						},
+						"serial_number": schema.StringAttribute{
+							Description: "Serial number.",
+							Computed:    true,
+						},
+						"part_number": schema.StringAttribute{
+							Description: "Part number.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *inventoryDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*msa.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type", "Expected *msa.Client")
+		return
+	}
+
+	d.client = client
+}
+
+func (d *inventoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data inventoryDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
+		return
+	}
+
+	includeFRUs := data.IncludeFRUs.IsNull() || data.IncludeFRUs.ValueBool()
+	items, err := readInventory(ctx, d.client, includeFRUs)
+	if err != nil {
+		detail := err.Error()
+		if errors.Is(err, msa.ErrResponseTooLarge) && includeFRUs {
+			detail += ". Set include_frus = false to skip the FRU listing."
+		}
+		resp.Diagnostics.AddError("Unable to query inventory", detail)
+		return
+	}
+
+	data.Items = inventoryItemModels(items)
+	data.Count = types.Int64Value(int64(len(data.Items)))
+	data.ID = types.StringValue("inventory")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// readInventory merges `show enclosures` with `show frus`. Firmware without
+// `show frus` only reports the enclosure view.
+func readInventory(ctx context.Context, client commandExecutor, includeFRUs bool) ([]msa.InventoryItem, error) {
+	response, err := client.Execute(ctx, "show", "enclosures")
+	if err != nil {
+		return nil, fmt.Errorf("show enclosures: %w", err)
+	}
+	items := msa.InventoryFromResponse(response)
+	if !includeFRUs {
+		return items, nil
+	}
+
+	response, err = client.Execute(ctx, "show", "frus")
+	if err != nil {
+		if isUnsupportedUsageProbeError(err) {
+			tflog.Debug(ctx, "show frus not supported; reporting enclosure inventory only", map[string]any{
+				"error": err.Error(),
+			})
+			return items, nil
+		}
+		return nil, fmt.Errorf("show frus: %w", err)
+	}
+	items = append(items, msa.InventoryFromResponse(response)...)
+	msa.SortInventory(items)
+	return items, nil
+}
+
+func inventoryItemModels(items []msa.InventoryItem) []inventoryItemModel {
+	models := make([]inventoryItemModel, 0, len(items))
+	for _, item := range items {
+		models = append(models, inventoryItemModel{
+			ID:           types.StringValue(item.ID),
+			Type:         types.StringValue(item.Type),
+			EnclosureID:  stringValueOrNull(item.EnclosureID),
+			Name:         stringValueOrNull(item.Name),
+			Status:       stringValueOrNull(item.Status),
+			Model:        stringValueOrNull(item.Model),
+			SerialNumber: stringValueOrNull(item.SerialNumber),
+			PartNumber:   stringValueOrNull(item.PartNumber),
+		})
+	}
+	return models
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+)
+
+func TestReadInventoryMergesEnclosuresAndFRUs(t *testing.T) {
+	object := func(baseType string, props ...string) msa.Object {
+		obj := msa.Object{BaseType: baseType}
+		for i := 0; i+1 < len(props); i += 2 {
+			obj.Properties = append(obj.Properties, msa.Property{Name: props[i], Value: props[i+1]})
+		}
+		return obj
+	}
+	client := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show enclosures": {response: msa.Response{Objects: []msa.Object{
+			object("power-supplies", "durable-id", "psu_0.0", "status", "Up", "serial-number", "PSU-SN"),
+			object("enclosures", "durable-id", "enclosure_0", "status", "OK", "midplane-serial-number", "MID-SN"),
+		}}},
+		"show frus": {response: msa.Response{Objects: []msa.Object{
+			object("enclosure-fru", "name", "RAID_IOM", "fru-location", "UPPER IOM SLOT", "enclosure-id", "0", "fru-status", "OK", "serial-number", "IOM-SN"),
+		}}},
+	}}
+
+	items, err := readInventory(context.Background(), client, true)
+	if err != nil {
+		t.Fatalf("read inventory: %v", err)
+	}
+	models := inventoryItemModels(items)
+	want := []string{"enclosure_0", "0/UPPER IOM SLOT", "psu_0.0"}
+	if len(models) != len(want) {
+		t.Fatalf("expected %d items, got %d", len(want), len(models))
+	}
+	for i, id := range want {
+		if models[i].ID.ValueString() != id {
+			t.Fatalf("item %d: expected %q, got %q", i, id, models[i].ID.ValueString())
+		}
+	}
+	if models[1].Type.ValueString() != "fru" || models[1].SerialNumber.ValueString() != "IOM-SN" {
+		t.Fatalf("unexpected FRU model %+v", models[1])
+	}
+	if !models[2].Model.IsNull() {
+		t.Fatalf("expected null model when the array reports none, got %v", models[2].Model)
+	}
+}
+
+func TestReadInventoryWithoutFRUSupport(t *testing.T) {
+	client := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show enclosures": {response: msa.Response{Objects: []msa.Object{{
+			BaseType:   "enclosures",
+			Properties: []msa.Property{{Name: "durable-id", Value: "enclosure_0"}},
+		}}}},
+	}}
+
+	items, err := readInventory(context.Background(), client, true)
+	if err != nil {
+		t.Fatalf("expected unsupported show frus to be skipped, got %v", err)
+	}
+	if len(items) != 1 || items[0].ID != "enclosure_0" {
+		t.Fatalf("unexpected items %+v", items)
+	}
+}
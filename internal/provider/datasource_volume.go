@@ -24,17 +24,22 @@ type volumeDataSource struct {
 }
 
 type volumeDataSourceModel struct {
-	Name         types.String `tfsdk:"name"`
-	NameRegex    types.String `tfsdk:"name_regex"`
-	ID           types.String `tfsdk:"id"`
-	SerialNumber types.String `tfsdk:"serial_number"`
-	DurableID    types.String `tfsdk:"durable_id"`
-	WWID         types.String `tfsdk:"wwid"`
-	SCSIWWN      types.String `tfsdk:"scsi_wwn"`
-	Pool         types.String `tfsdk:"pool"`
-	VDisk        types.String `tfsdk:"vdisk"`
-	Size         types.String `tfsdk:"size"`
-	Properties   types.Map    `tfsdk:"properties"`
+	Name           types.String `tfsdk:"name"`
+	NameRegex      types.String `tfsdk:"name_regex"`
+	ID             types.String `tfsdk:"id"`
+	SerialNumber   types.String `tfsdk:"serial_number"`
+	DurableID      types.String `tfsdk:"durable_id"`
+	WWID           types.String `tfsdk:"wwid"`
+	SCSIWWN        types.String `tfsdk:"scsi_wwn"`
+	Pool           types.String `tfsdk:"pool"`
+	VDisk          types.String `tfsdk:"vdisk"`
+	Size           types.String `tfsdk:"size"`
+	AllocatedSize  types.Int64  `tfsdk:"allocated_size"`
+	AllocatedPages types.Int64  `tfsdk:"allocated_pages"`
+	VolumeType     types.String `tfsdk:"volume_type"`
+	IsSnapshot     types.Bool   `tfsdk:"is_snapshot"`
+	Parent         types.String `tfsdk:"parent"`
+	Properties     types.Map    `tfsdk:"properties"`
 }
 
 func (d *volumeDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -84,6 +89,26 @@ func (d *volumeDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Description: "Volume size reported by the array.",
 				Computed:    true,
 			},
+			"allocated_size": schema.Int64Attribute{
+				Description: "Bytes actually allocated to the volume; below the advertised size for thin volumes.",
+				Computed:    true,
+			},
+			"allocated_pages": schema.Int64Attribute{
+				Description: "Number of 4 MiB pool pages allocated to the volume.",
+				Computed:    true,
+			},
+			"volume_type": schema.StringAttribute{
+				Description: "Volume type reported by the array (e.g. base, standard, snapshot).",
+				Computed:    true,
+			},
+			"is_snapshot": schema.BoolAttribute{
+				Description: "Whether the volume is a snapshot.",
+				Computed:    true,
+			},
+			"parent": schema.StringAttribute{
+				Description: "Name of the volume a snapshot was taken from; null for base volumes.",
+				Computed:    true,
+			},
 			"properties": schema.MapAttribute{
 				Description: "Raw properties returned by the XML API.",
 				Computed:    true,
@@ -190,7 +215,31 @@ func (d *volumeDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	data.Pool = types.StringValue(volume.PoolName)
 	data.VDisk = types.StringValue(volume.VDiskName)
 	data.Size = types.StringValue(volume.Size)
+	data.AllocatedSize = int64ValueOrNull(volume.AllocatedBytes())
+	data.AllocatedPages = int64ValueOrNull(volume.AllocatedPages())
+	data.VolumeType = types.StringValue(volume.VolumeType())
+	data.IsSnapshot = types.BoolValue(volume.IsSnapshot())
+	if parent := volumeParentName(volume, volumes); parent != "" {
+		data.Parent = types.StringValue(parent)
+	} else {
+		data.Parent = types.StringNull()
+	}
 	data.Properties = propsValue
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// volumeParentName resolves the parent of a snapshot to a volume name; the
+// array may report the parent by serial number.
+func volumeParentName(volume msa.Volume, volumes []msa.Volume) string {
+	parent := volume.Parent()
+	if parent == "" {
+		return ""
+	}
+	for _, candidate := range volumes {
+		if candidate.SerialNumber != "" && strings.EqualFold(candidate.SerialNumber, parent) {
+			return candidate.Name
+		}
+	}
+	return parent
+}
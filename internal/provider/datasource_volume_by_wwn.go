@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = (*volumeByWWNDataSource)(nil)
+
+func NewVolumeByWWNDataSource() datasource.DataSource {
+	return &volumeByWWNDataSource{}
+}
+
+type volumeByWWNDataSource struct {
+	client *msa.Client
+}
+
+type volumeByWWNDataSourceModel struct {
+	SCSIWWN      types.String `tfsdk:"scsi_wwn"`
+	NAA          types.String `tfsdk:"naa"`
+	WWN          types.String `tfsdk:"wwn"`
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	SerialNumber types.String `tfsdk:"serial_number"`
+	DurableID    types.String `tfsdk:"durable_id"`
+	Pool         types.String `tfsdk:"pool"`
+	VDisk        types.String `tfsdk:"vdisk"`
+}
+
+func (d *volumeByWWNDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_msa_volume_by_wwn"
+}
+
+func (d *volumeByWWNDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Finds the volume presenting a host-visible SCSI WWN/NAA identifier.",
+		Attributes: map[string]schema.Attribute{
+			"scsi_wwn": schema.StringAttribute{
+				Description: "Host-visible SCSI WWN (e.g. from /dev/mapper or /dev/disk/by-id). Separators, case, and 0x/naa./wwn-/multipath \"3\" prefixes are ignored. Kept as configured; the array's value is in wwn.",
+				Optional:    true,
+			},
+			"naa": schema.StringAttribute{
+				Description: "NAA identifier to look up (alternative to scsi_wwn).",
+				Optional:    true,
+			},
+			"wwn": schema.StringAttribute{
+				Description: "WWN of the matched volume as reported by the array.",
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "Volume identifier (serial number).",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "Volume name.",
+				Computed:    true,
+			},
+			"serial_number": schema.StringAttribute{
+				Description: "Volume serial number.",
+				Computed:    true,
+			},
+			"durable_id": schema.StringAttribute{
+				Description: "Durable ID reported by the array.",
+				Computed:    true,
+			},
+			"pool": schema.StringAttribute{
+				Description: "Pool name.",
+				Computed:    true,
+			},
+			"vdisk": schema.StringAttribute{
+				Description: "Virtual disk name.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *volumeByWWNDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*msa.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type", "Expected *msa.Client")
+		return
+	}
+
+	d.client = client
+}
+
+func (d *volumeByWWNDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data volumeByWWNDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
+		return
+	}
+
+	wwn := strings.TrimSpace(data.SCSIWWN.ValueString())
+	naa := strings.TrimSpace(data.NAA.ValueString())
+	if wwn == "" && naa == "" {
+		resp.Diagnostics.AddError("Invalid configuration", "either scsi_wwn or naa must be provided")
+		return
+	}
+	if wwn != "" && naa != "" {
+		resp.Diagnostics.AddError("Invalid configuration", "only one of scsi_wwn or naa can be provided")
+		return
+	}
+	lookup := firstNonEmpty(wwn, naa)
+	if normalizeSCSIWWN(lookup) == "" {
+		resp.Diagnostics.AddError("Invalid configuration", fmt.Sprintf("%q is not a usable WWN", lookup))
+		return
+	}
+
+	response, err := d.client.Execute(ctx, "show", "volumes")
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to query volumes", err.Error())
+		return
+	}
+
+	volume, ok := findVolumeByWWN(msa.VolumesFromResponse(response), lookup)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Volume not found",
+			fmt.Sprintf("No volume reported by the array has SCSI WWN %q (normalized %q).", lookup, normalizeSCSIWWN(lookup)),
+		)
+		return
+	}
+
+	data.WWN = types.StringValue(volume.WWN)
+	data.ID = types.StringValue(volume.SerialNumber)
+	data.Name = types.StringValue(volume.Name)
+	data.SerialNumber = types.StringValue(volume.SerialNumber)
+	data.DurableID = types.StringValue(volume.DurableID)
+	data.Pool = types.StringValue(volume.PoolName)
+	data.VDisk = types.StringValue(volume.VDiskName)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// normalizeSCSIWWN reduces the many host-side spellings of a volume WWN
+// (by-id links, multipath WWIDs, colon-separated, mixed case) to bare
+// lowercase hex so it can be compared with the array's wwn property.
+func normalizeSCSIWWN(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	value = strings.TrimPrefix(value, "/dev/disk/by-id/")
+	value = strings.TrimPrefix(value, "/dev/mapper/")
+	for _, prefix := range []string{"dm-uuid-mpath-", "scsi-", "wwn-", "naa.", "0x"} {
+		value = strings.TrimPrefix(value, prefix)
+	}
+	value = compactIdentity(value)
+	// Linux multipath prefixes NAA identifiers with the designator type "3".
+	if len(value) == 33 && strings.HasPrefix(value, "3") {
+		value = value[1:]
+	}
+	return value
+}
+
+func findVolumeByWWN(volumes []msa.Volume, wwn string) (msa.Volume, bool) {
+	wanted := normalizeSCSIWWN(wwn)
+	if wanted == "" {
+		return msa.Volume{}, false
+	}
+	for _, volume := range volumes {
+		if volume.WWN != "" && normalizeSCSIWWN(volume.WWN) == wanted {
+			return volume, true
+		}
+	}
+	return msa.Volume{}, false
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFindVolumeByWWN(t *testing.T) {
+	volumes := []msa.Volume{
+		{Name: "vol01", SerialNumber: "SN1", WWN: "600C0FF0003CAB9C1A2B3C4D01000000"},
+		{Name: "vol02", SerialNumber: "SN2", WWN: "600C0FF0003CAB9C1A2B3C4D02000000"},
+		{Name: "nowwn", SerialNumber: "SN3"},
+	}
+
+	testCases := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "exact", input: "600C0FF0003CAB9C1A2B3C4D01000000", want: "vol01"},
+		{name: "lowercase", input: "600c0ff0003cab9c1a2b3c4d02000000", want: "vol02"},
+		{name: "colon separated", input: "60:0c:0f:f0:00:3c:ab:9c:1a:2b:3c:4d:01:00:00:00", want: "vol01"},
+		{name: "naa prefix", input: "naa.600C0FF0003CAB9C1A2B3C4D02000000", want: "vol02"},
+		{name: "by-id link", input: "/dev/disk/by-id/wwn-0x600c0ff0003cab9c1a2b3c4d01000000", want: "vol01"},
+		{name: "multipath wwid", input: "3600c0ff0003cab9c1a2b3c4d02000000", want: "vol02"},
+		{name: "no match", input: "600c0ff0003cab9c1a2b3c4d09000000", want: ""},
+		{name: "empty", input: " ", want: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			volume, ok := findVolumeByWWN(volumes, tc.input)
+			if tc.want == "" {
+				if ok {
+					t.Fatalf("expected no match, got %q", volume.Name)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("expected %q to match", tc.input)
+			}
+			if volume.Name != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, volume.Name)
+			}
+		})
+	}
+}
+
+func TestVolumeByWWNReadKeepsConfiguredWWN(t *testing.T) {
+	server, _ := newMSATestServer(t, func(path string) string {
+		if path == "/api/show/volumes" {
+			return `<RESPONSE VERSION="L100"><OBJECT basetype="volumes" name="volume"><PROPERTY name="volume-name">vol01</PROPERTY>` +
+				`<PROPERTY name="serial-number">SN1</PROPERTY><PROPERTY name="wwn">600C0FF0003CAB9C1A2B3C4D01000000</PROPERTY></OBJECT></RESPONSE>`
+		}
+		return `<RESPONSE VERSION="L100"></RESPONSE>`
+	})
+	client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	d := &volumeByWWNDataSource{client: client}
+
+	ctx := context.Background()
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attrs := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		attrs[name] = tftypes.NewValue(attrType, nil)
+	}
+	configured := "/dev/disk/by-id/wwn-0x600c0ff0003cab9c1a2b3c4d01000000"
+	attrs["scsi_wwn"] = tftypes.NewValue(tftypes.String, configured)
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, attrs)}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read failed: %v", resp.Diagnostics)
+	}
+
+	var got volumeByWWNDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+	if got.SCSIWWN.ValueString() != configured {
+		t.Fatalf("expected scsi_wwn to stay %q, got %q", configured, got.SCSIWWN.ValueString())
+	}
+	if got.WWN.ValueString() != "600C0FF0003CAB9C1A2B3C4D01000000" || got.Name.ValueString() != "vol01" {
+		t.Fatalf("unexpected lookup result %+v", got)
+	}
+}
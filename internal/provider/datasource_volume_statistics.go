@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = (*volumeStatisticsDataSource)(nil)
+
+func NewVolumeStatisticsDataSource() datasource.DataSource {
+	return &volumeStatisticsDataSource{}
+}
+
+type volumeStatisticsDataSource struct {
+	client *msa.Client
+}
+
+type volumeStatisticsDataSourceModel struct {
+	ID         types.String            `tfsdk:"id"`
+	VolumeName types.String            `tfsdk:"volume_name"`
+	Count      types.Int64             `tfsdk:"count"`
+	Volumes    []volumeStatisticsModel `tfsdk:"volumes"`
+}
+
+type volumeStatisticsModel struct {
+	Name           types.String `tfsdk:"name"`
+	SerialNumber   types.String `tfsdk:"serial_number"`
+	ReadHits       types.Int64  `tfsdk:"read_hits"`
+	WriteHits      types.Int64  `tfsdk:"write_hits"`
+	IOPS           types.Int64  `tfsdk:"iops"`
+	BytesPerSecond types.Int64  `tfsdk:"bytes_per_second"`
+}
+
+func (d *volumeStatisticsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_msa_volume_statistics"
+}
+
+func (d *volumeStatisticsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Per-volume cache hit and throughput counters from `show volume-statistics`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier.",
+				Computed:    true,
+			},
+			"volume_name": schema.StringAttribute{
+				Description: "Limit the query to a single volume. Recommended on arrays with many volumes to keep the response small.",
+				Optional:    true,
+			},
+			"count": schema.Int64Attribute{
+				Description: "Number of volumes returned.",
+				Computed:    true,
+			},
+			"volumes": schema.ListNestedAttribute{
+				Description: "Statistics per volume, sorted by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Volume name.",
+							Computed:    true,
+						},
+						"serial_number": schema.StringAttribute{
+							Description: "Volume serial number.",
+							Computed:    true,
+						},
+						"read_hits": schema.Int64Attribute{
+							Description: "Read cache hits since the counters were last reset.",
+							Computed:    true,
+						},
+						"write_hits": schema.Int64Attribute{
+							Description: "Write cache hits since the counters were last reset.",
+							Computed:    true,
+						},
+						"iops": schema.Int64Attribute{
+							Description: "Current I/O operations per second.",
+							Computed:    true,
+						},
+						"bytes_per_second": schema.Int64Attribute{
+							Description: "Current data transfer rate in bytes per second.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *volumeStatisticsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*msa.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type", "Expected *msa.Client")
+		return
+	}
+
+	d.client = client
+}
+
+func (d *volumeStatisticsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data volumeStatisticsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
+		return
+	}
+
+	name := strings.TrimSpace(data.VolumeName.ValueString())
+	stats, err := readVolumeStatistics(ctx, d.client, name)
+	if err != nil {
+		detail := err.Error()
+		if errors.Is(err, msa.ErrResponseTooLarge) {
+			detail += ". Set volume_name to query a single volume."
+		}
+		resp.Diagnostics.AddError("Unable to query volume statistics", detail)
+		return
+	}
+
+	data.Volumes = volumeStatisticsModels(stats)
+	data.Count = types.Int64Value(int64(len(data.Volumes)))
+	if name != "" {
+		data.ID = types.StringValue(name)
+	} else {
+		data.ID = types.StringValue("volume-statistics")
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func readVolumeStatistics(ctx context.Context, client commandExecutor, name string) ([]msa.VolumeStatistics, error) {
+	parts := []string{"show", "volume-statistics"}
+	if name != "" {
+		parts = append(parts, name)
+	}
+	response, err := client.Execute(ctx, parts...)
+	if err != nil {
+		return nil, err
+	}
+	return msa.VolumeStatisticsFromResponse(response), nil
+}
+
+func volumeStatisticsModels(stats []msa.VolumeStatistics) []volumeStatisticsModel {
+	models := make([]volumeStatisticsModel, 0, len(stats))
+	for _, stat := range stats {
+		models = append(models, volumeStatisticsModel{
+			Name:           types.StringValue(stat.Name),
+			SerialNumber:   stringValueOrNull(stat.SerialNumber),
+			ReadHits:       types.Int64Value(stat.ReadHits),
+			WriteHits:      types.Int64Value(stat.WriteHits),
+			IOPS:           types.Int64Value(stat.IOPS),
+			BytesPerSecond: types.Int64Value(stat.BytesPerSecond),
+		})
+	}
+	return models
+}
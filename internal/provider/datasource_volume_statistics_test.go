@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+)
+
+func TestReadVolumeStatisticsSingleVolume(t *testing.T) {
+	client := fakeVolumeDeleteProbeClient{
+		results: map[string]fakeVolumeDeleteProbeResult{
+			"show volume-statistics vol01": {
+				response: msa.Response{
+					Objects: []msa.Object{
+						{
+							BaseType: "volume-statistics",
+							Properties: []msa.Property{
+								{Name: "volume-name", Value: "vol01"},
+								{Name: "serial-number", Value: "SN1"},
+								{Name: "read-cache-hits", Value: "42"},
+								{Name: "write-cache-hits", Value: "7"},
+								{Name: "iops", Value: "15"},
+								{Name: "bytes-per-second-numeric", Value: "2048"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	stats, err := readVolumeStatistics(context.Background(), client, "vol01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	models := volumeStatisticsModels(stats)
+	if len(models) != 1 {
+		t.Fatalf("expected 1 volume, got %d", len(models))
+	}
+	got := models[0]
+	if got.Name.ValueString() != "vol01" || got.SerialNumber.ValueString() != "SN1" {
+		t.Fatalf("unexpected identity %v/%v", got.Name, got.SerialNumber)
+	}
+	if got.ReadHits.ValueInt64() != 42 || got.WriteHits.ValueInt64() != 7 || got.IOPS.ValueInt64() != 15 || got.BytesPerSecond.ValueInt64() != 2048 {
+		t.Fatalf("unexpected counters %+v", got)
+	}
+}
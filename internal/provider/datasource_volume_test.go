@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+)
+
+func TestVolumeParentName(t *testing.T) {
+	volumes := []msa.Volume{
+		{Name: "db01", SerialNumber: "SN1", Properties: map[string]string{"volume-type": "base"}},
+		{Name: "db01-snap", SerialNumber: "SN2", Properties: map[string]string{"volume-type": "snapshot", "volume-parent": "SN1"}},
+		{Name: "db01-old", SerialNumber: "SN3", Properties: map[string]string{"base-volume": "retired"}},
+	}
+
+	testCases := []struct {
+		name   string
+		volume msa.Volume
+		want   string
+	}{
+		{name: "base volume", volume: volumes[0], want: ""},
+		{name: "parent serial resolved to name", volume: volumes[1], want: "db01"},
+		{name: "unknown parent kept as reported", volume: volumes[2], want: "retired"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := volumeParentName(tc.volume, volumes); got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
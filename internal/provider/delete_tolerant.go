@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// deleteTolerant runs a delete/unmap command and treats an array response
+// reporting the object as already gone as success, so destroy converges when
+// the object was removed outside Terraform.
+func deleteTolerant(ctx context.Context, client commandExecutor, parts ...string) error {
+	_, err := client.Execute(ctx, parts...)
+	if err == nil {
+		return nil
+	}
+	if isDeleteNotFoundError(err, parts) {
+		tflog.Debug(ctx, "MSA object already absent during delete", map[string]any{
+			"command": strings.Join(parts, " "),
+			"error":   err.Error(),
+		})
+		return nil
+	}
+	return err
+}
+
+// deleteTargetNouns maps the object keyword of each delete/unmap command the
+// provider sends to the words the array uses for that object in its error
+// messages. Snapshots are volumes to the array, and an unmap reports a gone
+// mapping by naming any side of it.
+var deleteTargetNouns = map[string][]string{
+	"volumes":            {"volume"},
+	"snapshot":           {"snapshot", "volume"},
+	"hosts":              {"host"},
+	"host-groups":        {"host group", "host-group", "hostgroup"},
+	"initiator-nickname": {"initiator", "nickname"},
+	"volume":             {"mapping", "volume", "initiator", "host"},
+}
+
+// isDeleteNotFoundError only considers array status responses; transport and
+// session failures are never treated as "already deleted". The message must
+// name the kind of object the command removes, so a missing pool or other
+// dependency is not mistaken for the object already being gone.
+func isDeleteNotFoundError(err error, parts []string) bool {
+	var apiErr msa.APIError
+	if !errors.As(err, &apiErr) || apiErr.PermissionDenied {
+		return false
+	}
+	if len(parts) < 2 {
+		return false
+	}
+	verb := strings.ToLower(parts[0])
+	nouns := deleteTargetNouns[strings.ToLower(parts[1])]
+
+	message := strings.ToLower(strings.TrimSpace(apiErr.Status.Response))
+	if verb == "unmap" && strings.Contains(message, "is not mapped") {
+		return true
+	}
+	return containsAny(message, nouns...) && containsAny(message,
+		"not found",
+		"does not exist",
+		"no such",
+	)
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+)
+
+func TestDeleteTolerant(t *testing.T) {
+	client := fakeVolumeDeleteProbeClient{
+		results: map[string]fakeVolumeDeleteProbeResult{
+			"delete volumes vol-ok": {},
+			"delete volumes vol-gone": {
+				err: msa.APIError{Status: msa.Status{Response: "Error: The volume was not found on this system."}},
+			},
+			"delete host-groups hg-gone": {
+				err: msa.APIError{Status: msa.Status{Response: "The specified host group does not exist."}},
+			},
+			"delete volumes vol-busy": {
+				err: msa.APIError{Status: msa.Status{Response: "Error: The volume is mapped to a host."}},
+			},
+			"delete volumes vol-pool": {
+				err: msa.APIError{Status: msa.Status{Response: "Error: The pool was not found on this system."}},
+			},
+			"unmap volume initiator host-a.* vol-unmapped": {
+				err: msa.APIError{Status: msa.Status{Response: "Error: The volume is not mapped to the specified initiator."}},
+			},
+			"delete volumes vol-transport": {
+				err: errors.New("request failed: not found"),
+			},
+		},
+	}
+
+	for _, target := range []string{"delete volumes vol-ok", "delete volumes vol-gone", "delete host-groups hg-gone", "unmap volume initiator host-a.* vol-unmapped"} {
+		if err := deleteTolerant(context.Background(), client, strings.Fields(target)...); err != nil {
+			t.Fatalf("%s: expected success, got %v", target, err)
+		}
+	}
+
+	for _, target := range []string{"delete volumes vol-busy", "delete volumes vol-pool", "delete volumes vol-transport"} {
+		if err := deleteTolerant(context.Background(), client, strings.Fields(target)...); err == nil {
+			t.Fatalf("%s: expected error to propagate", target)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -41,6 +42,29 @@ func acquireDestroyGlobalLock(ctx context.Context, owner string) (*destroyGlobal
 	return acquireDestroyGlobalLockWithOptions(ctx, owner, lockDir, wait)
 }
 
+// withDestroyLock acquires the destroy global lock once and runs teardown
+// under it, so the pre-delete probe, unmap and delete of one resource are not
+// interleaved with another teardown against the same array.
+func withDestroyLock(ctx context.Context, owner string, teardown func() diag.Diagnostics) diag.Diagnostics {
+	var diags diag.Diagnostics
+	lock, err := acquireDestroyGlobalLock(ctx, owner)
+	if err != nil {
+		diags.AddError("Unable to acquire destroy global lock", err.Error())
+		return diags
+	}
+	defer func() {
+		if releaseErr := lock.Release(ctx); releaseErr != nil {
+			tflog.Warn(ctx, "release MSA destroy global lock failed", map[string]any{
+				"lock_owner": owner,
+				"error":      releaseErr.Error(),
+			})
+		}
+	}()
+
+	diags.Append(teardown()...)
+	return diags
+}
+
 func acquireDestroyGlobalLockWithOptions(ctx context.Context, owner, lockDir string, wait time.Duration) (*destroyGlobalLock, error) {
 	lockDir = strings.TrimSpace(lockDir)
 	if lockDir == "" {
@@ -61,6 +85,9 @@ func acquireDestroyGlobalLockWithOptions(ctx context.Context, owner, lockDir str
 
 	deadline := time.Now().Add(wait)
 	for {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("context canceled while waiting for destroy global lock %q: %w", lockDir, ctxErr)
+		}
 		err := os.Mkdir(lockDir, 0o700)
 		if err == nil {
 			lock := &destroyGlobalLock{
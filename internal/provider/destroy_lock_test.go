@@ -8,8 +8,15 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestAcquireDestroyGlobalLockWithOptions(t *testing.T) {
@@ -167,3 +174,155 @@ func TestAcquireDestroyGlobalLockWithOptionsReclaimsStaleDeadPID(t *testing.T) {
 		t.Fatalf("owner file not replaced after dead pid reclaim: %q", string(ownerRaw))
 	}
 }
+
+func TestAcquireDestroyGlobalLockWithOptionsCancelledWhileWaiting(t *testing.T) {
+	t.Parallel()
+
+	lockDir := filepath.Join(t.TempDir(), "destroy-lock-cancel.d")
+	holder, err := acquireDestroyGlobalLockWithOptions(context.Background(), "first-owner", lockDir, 2*time.Second)
+	if err != nil {
+		t.Fatalf("acquire first lock: %v", err)
+	}
+	defer func() {
+		_ = holder.Release(context.Background())
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = acquireDestroyGlobalLockWithOptions(ctx, "second-owner", lockDir, time.Minute)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context cancellation, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected prompt return after cancellation, took %s", elapsed)
+	}
+
+	ownerRaw, err := os.ReadFile(filepath.Join(lockDir, "owner"))
+	if err != nil {
+		t.Fatalf("read owner file: %v", err)
+	}
+	if !strings.Contains(string(ownerRaw), "owner=first-owner") {
+		t.Fatalf("cancelled waiter must not disturb the held lock, got %q", string(ownerRaw))
+	}
+}
+
+func TestAcquireDestroyGlobalLockWithOptionsCancelledBeforeAcquire(t *testing.T) {
+	t.Parallel()
+
+	lockDir := filepath.Join(t.TempDir(), "destroy-lock-precancel.d")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := acquireDestroyGlobalLockWithOptions(ctx, "owner", lockDir, time.Minute); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context cancellation, got %v", err)
+	}
+	if _, err := os.Stat(lockDir); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("lock should not be taken after cancellation, stat err=%v", err)
+	}
+}
+
+func TestWithDestroyLockSerializesConcurrentTeardowns(t *testing.T) {
+	lockDir := filepath.Join(t.TempDir(), "destroy-lock.d")
+	t.Setenv("HPE_MSA_DESTROY_GLOBAL_LOCK_DIR", lockDir)
+
+	ctx := context.Background()
+	var active, maxActive, completed atomic.Int32
+	var wg sync.WaitGroup
+	errs := make(chan diag.Diagnostics, 3)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- withDestroyLock(ctx, "teardown-"+strconv.Itoa(i), func() diag.Diagnostics {
+				current := active.Add(1)
+				for {
+					seen := maxActive.Load()
+					if current <= seen || maxActive.CompareAndSwap(seen, current) {
+						break
+					}
+				}
+				time.Sleep(50 * time.Millisecond)
+				active.Add(-1)
+				completed.Add(1)
+				return nil
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for diags := range errs {
+		if diags.HasError() {
+			t.Fatalf("unexpected teardown diagnostics: %v", diags)
+		}
+	}
+	if got := completed.Load(); got != 3 {
+		t.Fatalf("expected 3 teardowns to complete, got %d", got)
+	}
+	if got := maxActive.Load(); got != 1 {
+		t.Fatalf("expected teardowns to run one at a time, saw %d concurrently", got)
+	}
+	if _, err := os.Stat(lockDir); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("lock dir should be removed, stat err=%v", err)
+	}
+}
+
+func TestCloneAndMappingDeletesProbeUnderDestroyLock(t *testing.T) {
+	lockDir := filepath.Join(t.TempDir(), "destroy-lock.d")
+	t.Setenv("HPE_MSA_DESTROY_GLOBAL_LOCK_DIR", lockDir)
+
+	var mu sync.Mutex
+	unlocked := make([]string, 0)
+	server, _ := newMSATestServer(t, func(path string) string {
+		if _, err := os.Stat(lockDir); err != nil {
+			mu.Lock()
+			unlocked = append(unlocked, path)
+			mu.Unlock()
+		}
+		return `<RESPONSE VERSION="L100"></RESPONSE>`
+	})
+	client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	clone := &cloneResource{client: client}
+	cloneState := resourceState(t, clone, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, "SN-C"),
+		"name":          tftypes.NewValue(tftypes.String, "clone-a"),
+		"allow_destroy": tftypes.NewValue(tftypes.Bool, true),
+	})
+	mapping := &volumeMappingResource{client: client}
+	mappingState := resourceState(t, mapping, map[string]tftypes.Value{
+		"volume_name": tftypes.NewValue(tftypes.String, "vol-a"),
+		"target_type": tftypes.NewValue(tftypes.String, "initiator"),
+		"target_name": tftypes.NewValue(tftypes.String, "iqn.1991-05.com.example:host-a"),
+	})
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	cloneResp := resource.DeleteResponse{State: cloneState}
+	mappingResp := resource.DeleteResponse{State: mappingState}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		clone.Delete(ctx, resource.DeleteRequest{State: cloneState}, &cloneResp)
+	}()
+	go func() {
+		defer wg.Done()
+		mapping.Delete(ctx, resource.DeleteRequest{State: mappingState}, &mappingResp)
+	}()
+	wg.Wait()
+
+	if cloneResp.Diagnostics.HasError() {
+		t.Fatalf("clone delete diagnostics: %v", cloneResp.Diagnostics)
+	}
+	if mappingResp.Diagnostics.HasError() {
+		t.Fatalf("mapping delete diagnostics: %v", mappingResp.Diagnostics)
+	}
+	if len(unlocked) > 0 {
+		t.Fatalf("expected probes and deletes to run under the destroy lock, unlocked calls: %v", unlocked)
+	}
+}
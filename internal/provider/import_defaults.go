@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// setImportDefaults records false for boolean attributes whose schema
+// default is false. Defaults are only applied when planning from
+// configuration, so an import that leaves them null makes the first plan
+// show an update to false.
+func setImportDefaults(ctx context.Context, resp *resource.ImportStateResponse, attributes ...string) {
+	for _, name := range attributes {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(name), false)...)
+	}
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestImportStateSetsDefaultFalseFlags(t *testing.T) {
+	cases := []struct {
+		name       string
+		resource   resource.ResourceWithImportState
+		id         string
+		attributes []string
+	}{
+		{"clone", &cloneResource{}, "00c0ff0000000001", []string{"auto_suffix_on_collision", "allow_destroy", "adopt_existing"}},
+		{"host", &hostResource{}, "HostA", []string{"allow_destroy", "adopt_existing"}},
+		{"host_group", &hostGroupResource{}, "Group1", []string{"allow_destroy", "adopt_existing"}},
+		{"initiator", &initiatorResource{}, "iqn.1998-01.com.example:host1", []string{"allow_destroy", "allow_duplicate_nickname"}},
+		{"snapshot", &snapshotResource{}, "00c0ff0000000002", []string{"allow_destroy", "adopt_existing"}},
+		{"volume", &volumeResource{}, "00c0ff0000000003", []string{"verify_unmapped", "allow_destroy", "adopt_existing"}},
+		{"volume_mapping", &volumeMappingResource{}, "vol1:host:HostA", []string{"check_active_sessions", "force", "skip_volume_check"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			resp := resource.ImportStateResponse{State: resourceState(t, tc.resource, nil)}
+			tc.resource.ImportState(ctx, resource.ImportStateRequest{ID: tc.id}, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("import: %v", resp.Diagnostics)
+			}
+			for _, name := range tc.attributes {
+				var got types.Bool
+				resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root(name), &got)...)
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("get %s: %v", name, resp.Diagnostics)
+				}
+				if got.IsNull() || got.ValueBool() {
+					t.Fatalf("expected %s to be false after import, got %v", name, got)
+				}
+			}
+		})
+	}
+}
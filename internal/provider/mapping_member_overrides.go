@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// memberLUNOverridesFromModel validates member_lun_overrides. Overrides only
+// apply to host-group mappings, where the group LUN is otherwise presented
+// to every member host.
+func memberLUNOverridesFromModel(ctx context.Context, targetType types.String, value types.Map) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.IsNull() || value.IsUnknown() {
+		return nil, diags
+	}
+
+	raw := make(map[string]string)
+	diags.Append(value.ElementsAs(ctx, &raw, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if len(raw) == 0 {
+		return nil, diags
+	}
+	if strings.TrimSpace(targetType.ValueString()) != "host_group" {
+		diags.AddError("Invalid member_lun_overrides", "member_lun_overrides is only supported when target_type is host_group")
+		return nil, diags
+	}
+
+	overrides := make(map[string]string, len(raw))
+	for host, lun := range raw {
+		host = strings.TrimSpace(host)
+		lun = strings.TrimSpace(lun)
+		if host == "" {
+			diags.AddError("Invalid member_lun_overrides", "host names must not be empty")
+			continue
+		}
+		if !isDigits(lun) {
+			diags.AddError("Invalid member_lun_overrides", fmt.Sprintf("LUN %q for host %q must be a non-negative integer", lun, host))
+			continue
+		}
+		overrides[host] = lun
+	}
+	return overrides, diags
+}
+
+func memberOverrideHosts(overrides map[string]string) []string {
+	hosts := make([]string, 0, len(overrides))
+	for host := range overrides {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// memberOverrideCommand maps the volume to a single member host with its own
+// LUN. The host-level mapping takes precedence over the group mapping for
+// that host's initiators.
+func memberOverrideCommand(access string, ports []string, lun, host, volume string) []string {
+	parts := []string{"map", "volume"}
+	if access != "" {
+		parts = append(parts, "access", access)
+	}
+	if len(ports) > 0 {
+		parts = append(parts, "ports", strings.Join(ports, ","))
+	}
+	return append(parts, "lun", lun, "initiator", host+".*", volume)
+}
+
+// applyMemberLUNOverrides issues one host-level map per override, in host
+// order, after the group mapping exists.
+func applyMemberLUNOverrides(ctx context.Context, client commandExecutor, access string, ports []string, volume string, overrides map[string]string) error {
+	for _, host := range memberOverrideHosts(overrides) {
+		if _, err := client.Execute(ctx, memberOverrideCommand(access, ports, overrides[host], host, volume)...); err != nil {
+			return fmt.Errorf("override LUN %s for host %q: %w", overrides[host], host, err)
+		}
+	}
+	return nil
+}
+
+// readMemberLUNOverrides reports the LUN each overridden host currently
+// sees. Hosts whose override mapping is gone are omitted so the next plan
+// restores them.
+func readMemberLUNOverrides(ctx context.Context, client commandExecutor, volume string, overrides map[string]string) (map[string]string, error) {
+	current := make(map[string]string, len(overrides))
+	for _, host := range memberOverrideHosts(overrides) {
+		mapping, err := lookupMapping(ctx, client, volume, host+".*")
+		if err != nil {
+			if errors.Is(err, errMappingNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("read LUN override for host %q: %w", host, err)
+		}
+		if mapping.LUN != "" {
+			current[host] = mapping.LUN
+		}
+	}
+	return current, nil
+}
+
+// removeMemberLUNOverrides unmaps the host-level overrides before the group
+// mapping is removed.
+func removeMemberLUNOverrides(ctx context.Context, client commandExecutor, volume string, overrides map[string]string) error {
+	for _, host := range memberOverrideHosts(overrides) {
+		if err := deleteTolerant(ctx, client, "unmap", "volume", "initiator", host+".*", volume); err != nil {
+			return fmt.Errorf("remove LUN override for host %q: %w", host, err)
+		}
+	}
+	return nil
+}
+
+func memberLUNOverridesValue(ctx context.Context, overrides map[string]string) (types.Map, diag.Diagnostics) {
+	if overrides == nil {
+		return types.MapNull(types.StringType), nil
+	}
+	return types.MapValueFrom(ctx, types.StringType, overrides)
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// objectLimitPattern matches array messages that tie a limit to the volume
+// or snapshot count itself. Generic limit wording ("exceeds the maximum",
+// "maximum number of hosts") also appears for sizes and mappings, which are
+// not object-limit failures.
+var objectLimitPattern = regexp.MustCompile(
+	`maximum (allowed )?number of (volumes|snapshots)|` +
+		`(volume|snapshot) (count )?limit (has been |was )?(reached|exceeded)|` +
+		`too many (volumes|snapshots)`,
+)
+
+// isObjectLimitError reports whether a create command was rejected because
+// the array reached its maximum number of volumes or snapshots.
+func isObjectLimitError(err error) bool {
+	var apiErr msa.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return objectLimitPattern.MatchString(strings.ToLower(apiErr.Status.Response))
+}
+
+// objectLimitDiagnostic builds a terminal diagnostic for an object-limit
+// failure, including the current object count when the array reports it.
+func objectLimitDiagnostic(ctx context.Context, client commandExecutor, entity string, err error) (string, string) {
+	plural := entity + "s"
+	countHint := ""
+	if count, ok := currentObjectCount(ctx, client, entity); ok {
+		countHint = fmt.Sprintf(" The array currently reports %d %s.", count, pluralize(count, entity, plural))
+	}
+
+	summary := fmt.Sprintf("Array %s limit reached", entity)
+	detail := fmt.Sprintf(
+		"The array refused to create the %s because its maximum number of %s has been reached.%s Delete unused %s (or consolidate workloads) before retrying; this error is not retryable. Array response: %s",
+		entity,
+		plural,
+		countHint,
+		plural,
+		err.Error(),
+	)
+	return summary, detail
+}
+
+func currentObjectCount(ctx context.Context, client commandExecutor, entity string) (int, bool) {
+	if client == nil {
+		return 0, false
+	}
+
+	switch entity {
+	case "volume":
+		response, err := client.Execute(ctx, "show", "volumes")
+		if err != nil {
+			tflog.Debug(ctx, "unable to count volumes for limit diagnostic", map[string]any{"error": err.Error()})
+			return 0, false
+		}
+		// `show volumes` also lists snapshots, which count against the
+		// snapshot limit instead.
+		count := 0
+		for _, volume := range msa.VolumesFromResponse(response) {
+			if !volume.IsSnapshot() {
+				count++
+			}
+		}
+		return count, true
+	case "snapshot":
+		response, err := client.Execute(ctx, "show", "snapshots")
+		if err != nil {
+			tflog.Debug(ctx, "unable to count snapshots for limit diagnostic", map[string]any{"error": err.Error()})
+			return 0, false
+		}
+		return len(msa.SnapshotsFromResponse(response)), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func limitAPIError(message string) error {
+	return msa.APIError{
+		Status: msa.Status{
+			ResponseType:        "Error",
+			ResponseTypeNumeric: 1,
+			Response:            message,
+			ReturnCode:          -1,
+		},
+	}
+}
+
+func TestIsObjectLimitError(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "volume limit", err: limitAPIError("Error: The maximum number of volumes for the system has been reached."), want: true},
+		{name: "snapshot limit", err: limitAPIError("Error: Unable to create the snapshot. The snapshot limit has been reached."), want: true},
+		{name: "name in use", err: limitAPIError("Error: The name is already in use."), want: false},
+		{name: "insufficient space", err: limitAPIError("Error: Insufficient free space in the pool."), want: false},
+		{name: "volume size maximum", err: limitAPIError("Error: The specified size exceeds the maximum volume size."), want: false},
+		{name: "mapping host limit", err: limitAPIError("Error: The maximum number of hosts for this volume has been reached."), want: false},
+		{name: "transport error", err: errors.New("maximum number of volumes"), want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isObjectLimitError(tc.err); got != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestObjectLimitDiagnosticVolumeIncludesCount(t *testing.T) {
+	client := fakeVolumeDeleteProbeClient{
+		results: map[string]fakeVolumeDeleteProbeResult{
+			"show volumes": {
+				response: msa.Response{
+					Objects: []msa.Object{
+						{BaseType: "volumes", Properties: []msa.Property{{Name: "volume-name", Value: "vol01"}}},
+						{BaseType: "volumes", Properties: []msa.Property{{Name: "volume-name", Value: "vol02"}}},
+					},
+				},
+			},
+		},
+	}
+
+	err := limitAPIError("Error: The maximum number of volumes for the system has been reached.")
+	summary, detail := objectLimitDiagnostic(context.Background(), client, "volume", err)
+	if summary != "Array volume limit reached" {
+		t.Fatalf("unexpected summary %q", summary)
+	}
+	if !strings.Contains(detail, "currently reports 2 volumes") {
+		t.Fatalf("expected current count in detail, got %q", detail)
+	}
+	if !strings.Contains(detail, "not retryable") {
+		t.Fatalf("expected non-retryable hint, got %q", detail)
+	}
+}
+
+func TestObjectLimitDiagnosticSnapshotWithoutCount(t *testing.T) {
+	client := fakeVolumeDeleteProbeClient{}
+
+	err := limitAPIError("Error: The snapshot limit has been reached.")
+	summary, detail := objectLimitDiagnostic(context.Background(), client, "snapshot", err)
+	if summary != "Array snapshot limit reached" {
+		t.Fatalf("unexpected summary %q", summary)
+	}
+	if strings.Contains(detail, "currently reports") {
+		t.Fatalf("expected no count when show snapshots fails, got %q", detail)
+	}
+	if !strings.Contains(detail, "Delete unused snapshots") {
+		t.Fatalf("expected cleanup suggestion, got %q", detail)
+	}
+}
+
+func TestCreateReportsObjectLimit(t *testing.T) {
+	fixture := func(name string) string {
+		raw, err := os.ReadFile(filepath.Join("..", "msa", "testdata", name))
+		if err != nil {
+			t.Fatalf("read fixture: %v", err)
+		}
+		return string(raw)
+	}
+	// show_volumes_snapshot.xml lists one base volume and one snapshot.
+	volumes := fixture("show_volumes_snapshot.xml")
+	snapshots := fixture("show_snapshots.xml")
+	limitResponse := func(message string) string {
+		return `<RESPONSE VERSION="L100"><OBJECT basetype="status" name="status"><PROPERTY name="response-type">Error</PROPERTY>` +
+			`<PROPERTY name="response-type-numeric">1</PROPERTY><PROPERTY name="response">` + message + `</PROPERTY>` +
+			`<PROPERTY name="return-code">-1</PROPERTY></OBJECT></RESPONSE>`
+	}
+	server, paths := newMSATestServer(t, func(path string) string {
+		switch {
+		case path == "/api/show/volumes":
+			return volumes
+		case path == "/api/show/snapshots":
+			return snapshots
+		case path == "/api/show/pools":
+			return `<RESPONSE VERSION="L100"><OBJECT basetype="pools" name="pool"><PROPERTY name="name">A</PROPERTY></OBJECT></RESPONSE>`
+		case strings.HasPrefix(path, "/api/create/snapshots/"):
+			return limitResponse("Error: The snapshot limit has been reached.")
+		case strings.HasPrefix(path, "/api/create/volume/"), strings.HasPrefix(path, "/api/copy/volume/"):
+			return limitResponse("Error: The maximum number of volumes for the system has been reached.")
+		}
+		return `<RESPONSE VERSION="L100"></RESPONSE>`
+	})
+	client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	create := func(r resource.Resource, values map[string]tftypes.Value) resource.CreateResponse {
+		*paths = nil
+		planned := resourceState(t, r, values)
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: planned.Schema}}
+		r.Create(context.Background(), resource.CreateRequest{
+			Plan:   tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw},
+			Config: tfsdk.Config{Schema: planned.Schema, Raw: planned.Raw},
+		}, &resp)
+		return resp
+	}
+	expectLimit := func(t *testing.T, resp resource.CreateResponse, summary, count string) {
+		t.Helper()
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected the create to fail")
+		}
+		got := resp.Diagnostics.Errors()[0]
+		if got.Summary() != summary || !strings.Contains(got.Detail(), count) || !strings.Contains(got.Detail(), "not retryable") {
+			t.Fatalf("expected %q reporting %q, got %q: %q", summary, count, got.Summary(), got.Detail())
+		}
+	}
+
+	t.Run("volume", func(t *testing.T) {
+		resp := create(&volumeResource{client: client}, map[string]tftypes.Value{
+			"name": tftypes.NewValue(tftypes.String, "vol-new"),
+			"size": tftypes.NewValue(tftypes.String, "10GB"),
+			"pool": tftypes.NewValue(tftypes.String, "A"),
+		})
+		// The snapshot that show volumes also lists is not counted.
+		expectLimit(t, resp, "Array volume limit reached", "currently reports 1 volume.")
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		resp := create(&snapshotResource{client: client}, map[string]tftypes.Value{
+			"name":        tftypes.NewValue(tftypes.String, "snap-new"),
+			"volume_name": tftypes.NewValue(tftypes.String, "db01"),
+		})
+		expectLimit(t, resp, "Array snapshot limit reached", "currently reports 1 snapshot.")
+	})
+
+	t.Run("clone", func(t *testing.T) {
+		resp := create(&cloneResource{client: client}, map[string]tftypes.Value{
+			"name":            tftypes.NewValue(tftypes.String, "clone-new"),
+			"source_snapshot": tftypes.NewValue(tftypes.String, "db01-snap"),
+		})
+		expectLimit(t, resp, "Array volume limit reached", "currently reports 1 volume.")
+		for _, path := range *paths {
+			if strings.HasPrefix(path, "/api/copy/volume/") {
+				return
+			}
+		}
+		t.Fatalf("expected a copy volume command, got %v", *paths)
+	})
+}
@@ -27,6 +27,11 @@ func New(version string) func() provider.Provider {
 
 type msaProvider struct {
 	version string
+
+	// auditLog is the log opened by the last Configure. The provider owns
+	// it and closes it when a later Configure replaces it; lines are written
+	// unbuffered, so the file needs no close at process exit.
+	auditLog *msa.AuditLog
 }
 
 type providerConfig struct {
@@ -35,6 +40,15 @@ type providerConfig struct {
 	Password    types.String `tfsdk:"password"`
 	InsecureTLS types.Bool   `tfsdk:"insecure_tls"`
 	Timeout     types.String `tfsdk:"timeout"`
+
+	ValidateOnConfigure types.Bool   `tfsdk:"validate_on_configure"`
+	AuditLogPath        types.String `tfsdk:"audit_log_path"`
+	ForceEnglish        types.Bool   `tfsdk:"force_english_messages"`
+	ReadOnly            types.Bool   `tfsdk:"read_only"`
+
+	DefaultMappingAccess types.String `tfsdk:"default_mapping_access"`
+	AllowedCommands      types.List   `tfsdk:"allowed_commands"`
+	DeniedCommands       types.List   `tfsdk:"denied_commands"`
 }
 
 type resolvedConfig struct {
@@ -43,6 +57,15 @@ type resolvedConfig struct {
 	Password    string
 	InsecureTLS bool
 	Timeout     time.Duration
+
+	ValidateOnConfigure bool
+	AuditLogPath        string
+	ForceEnglish        bool
+	ReadOnly            bool
+
+	DefaultMappingAccess string
+	AllowedCommands      []string
+	DeniedCommands       []string
 }
 
 func (p *msaProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -74,6 +97,36 @@ func (p *msaProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *
 				Description: "HTTP client timeout (e.g., 30s).",
 				Optional:    true,
 			},
+			"validate_on_configure": schema.BoolAttribute{
+				Description: "Log in and run `show system` while configuring the provider so endpoint, credential, and TLS problems surface immediately. Defaults to false (can also be set via MSA_VALIDATE_ON_CONFIGURE).",
+				Optional:    true,
+			},
+			"audit_log_path": schema.StringAttribute{
+				Description: "Append one JSON line per array command (timestamp, command with secrets redacted, result status) to this file. Can also be set via MSA_AUDIT_LOG_PATH.",
+				Optional:    true,
+			},
+			"force_english_messages": schema.BoolAttribute{
+				Description: "Switch each session's CLI locale to English (`set cli-parameters locale English`) so status messages are not localized. Only the provider's sessions are affected. Skipped when read_only is set; the command is subject to allowed_commands/denied_commands and is audited like any other. Defaults to false (can also be set via MSA_FORCE_ENGLISH).",
+				Optional:    true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "Reject every array command except `show` before it is sent, so plan-only and audit runs cannot change the array even if a resource attempts to. Defaults to false (can also be set via MSA_READ_ONLY).",
+				Optional:    true,
+			},
+			"default_mapping_access": schema.StringAttribute{
+				Description: "Access applied to `hpe_msa_volume_mapping` resources that omit `access`: read-write (rw), read-only (ro), or no-access. Defaults to read-write (can also be set via MSA_DEFAULT_MAPPING_ACCESS).",
+				Optional:    true,
+			},
+			"allowed_commands": schema.ListAttribute{
+				Description: "Only run array commands matching one of these patterns: a verb (`delete`) or a verb and object (`create volume`, `* snapshot`). `show` commands are always allowed. Can also be set via MSA_ALLOWED_COMMANDS (comma-separated).",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"denied_commands": schema.ListAttribute{
+				Description: "Reject array commands matching any of these patterns (same syntax as allowed_commands, e.g. `delete host`). Takes precedence over allowed_commands. Can also be set via MSA_DENIED_COMMANDS (comma-separated).",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 		},
 	}
 }
@@ -91,12 +144,33 @@ func (p *msaProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
+	if err := p.auditLog.Close(); err != nil {
+		tflog.Warn(ctx, "Unable to close the previous MSA audit log", map[string]any{"error": err.Error()})
+	}
+	p.auditLog = nil
+	if resolved.AuditLogPath != "" {
+		log, err := msa.OpenAuditLog(resolved.AuditLogPath)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to open audit log", err.Error())
+			return
+		}
+		p.auditLog = log
+	}
+
 	client, err := msa.NewClient(msa.Config{
 		Endpoint:    resolved.Endpoint,
 		Username:    resolved.Username,
 		Password:    resolved.Password,
 		InsecureTLS: resolved.InsecureTLS,
 		Timeout:     resolved.Timeout,
+		AuditLog:    p.auditLog,
+
+		ForceEnglish: resolved.ForceEnglish,
+		ReadOnly:     resolved.ReadOnly,
+
+		DefaultMappingAccess: resolved.DefaultMappingAccess,
+		AllowedCommands:      resolved.AllowedCommands,
+		DeniedCommands:       resolved.DeniedCommands,
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to create MSA client", err.Error())
@@ -107,6 +181,13 @@ func (p *msaProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		tflog.Warn(ctx, "TLS certificate verification is disabled")
 	}
 
+	if resolved.ValidateOnConfigure {
+		resp.Diagnostics.Append(validateConnection(ctx, client, resolved.Endpoint)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
@@ -121,6 +202,7 @@ func (p *msaProvider) Resources(_ context.Context) []func() resource.Resource {
 		NewHostResource,
 		NewHostInitiatorResource,
 		NewVolumeMappingResource,
+		NewProtocolsResource,
 	}
 }
 
@@ -129,6 +211,12 @@ func (p *msaProvider) DataSources(_ context.Context) []func() datasource.DataSou
 		NewPoolDataSource,
 		NewHostDataSource,
 		NewVolumeDataSource,
+		NewCurrentUserDataSource,
+		NewVolumeByWWNDataSource,
+		NewVolumeStatisticsDataSource,
+		NewArrayTimeDataSource,
+		NewInventoryDataSource,
+		NewControllerCacheDataSource,
 	}
 }
 
@@ -143,6 +231,27 @@ func resolveConfig(config providerConfig) (resolvedConfig, diag.Diagnostics) {
 	diags.Append(d...)
 	insecureTLS, d := boolOrEnv(config.InsecureTLS, "MSA_INSECURE_TLS")
 	diags.Append(d...)
+	validateOnConfigure, d := boolOrEnv(config.ValidateOnConfigure, "MSA_VALIDATE_ON_CONFIGURE")
+	diags.Append(d...)
+	auditLogPath, d := stringOrEnv(config.AuditLogPath, "MSA_AUDIT_LOG_PATH")
+	diags.Append(d...)
+	forceEnglish, d := boolOrEnv(config.ForceEnglish, "MSA_FORCE_ENGLISH")
+	diags.Append(d...)
+	readOnly, d := boolOrEnv(config.ReadOnly, "MSA_READ_ONLY")
+	diags.Append(d...)
+	defaultMappingAccess, d := stringOrEnv(config.DefaultMappingAccess, "MSA_DEFAULT_MAPPING_ACCESS")
+	diags.Append(d...)
+	allowedCommands, d := listOrEnv(config.AllowedCommands, "MSA_ALLOWED_COMMANDS")
+	diags.Append(d...)
+	deniedCommands, d := listOrEnv(config.DeniedCommands, "MSA_DENIED_COMMANDS")
+	diags.Append(d...)
+	if defaultMappingAccess != "" {
+		access, accessDiags := normalizeAccess(types.StringValue(defaultMappingAccess))
+		if accessDiags.HasError() {
+			diags.AddError("Invalid default_mapping_access", fmt.Sprintf("%q is not a valid access level; use read-write, read-only, no-access, rw, or ro", defaultMappingAccess))
+		}
+		defaultMappingAccess = access
+	}
 
 	var timeout time.Duration
 	if config.Timeout.IsUnknown() {
@@ -174,5 +283,27 @@ func resolveConfig(config providerConfig) (resolvedConfig, diag.Diagnostics) {
 		Password:    password,
 		InsecureTLS: insecureTLS,
 		Timeout:     timeout,
+
+		ValidateOnConfigure: validateOnConfigure,
+		AuditLogPath:        auditLogPath,
+		ForceEnglish:        forceEnglish,
+		ReadOnly:            readOnly,
+
+		DefaultMappingAccess: defaultMappingAccess,
+		AllowedCommands:      allowedCommands,
+		DeniedCommands:       deniedCommands,
 	}, diags
 }
+
+func validateConnection(ctx context.Context, client commandExecutor, endpoint string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if _, err := client.Execute(ctx, "show", "system"); err != nil {
+		diags.AddError(
+			"Unable to connect to MSA",
+			fmt.Sprintf("validate_on_configure: `show system` against %s failed: %v. Check the endpoint, credentials, and TLS settings (insecure_tls) for the provider.", endpoint, err),
+		)
+	}
+
+	return diags
+}
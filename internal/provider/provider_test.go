@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// providerConfigureRequest builds a ConfigureRequest from the provider schema,
+// leaving every attribute not present in values null.
+func providerConfigureRequest(t *testing.T, p provider.Provider, values map[string]tftypes.Value) provider.ConfigureRequest {
+	t.Helper()
+
+	ctx := context.Background()
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("provider schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("provider schema is not an object type")
+	}
+
+	attrs := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attrs[name] = value
+			continue
+		}
+		attrs[name] = tftypes.NewValue(attrType, nil)
+	}
+
+	return provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objectType, attrs),
+		},
+	}
+}
+
+func clearProviderEnv(t *testing.T) {
+	t.Helper()
+	for _, env := range []string{"MSA_ENDPOINT", "MSA_USERNAME", "MSA_PASSWORD", "MSA_INSECURE_TLS", "MSA_VALIDATE_ON_CONFIGURE", "MSA_READ_ONLY", "MSA_DEFAULT_MAPPING_ACCESS", "MSA_ALLOWED_COMMANDS", "MSA_DENIED_COMMANDS", "MSA_AUDIT_LOG_PATH", "MSA_FORCE_ENGLISH"} {
+		t.Setenv(env, "")
+	}
+}
+
+func TestProviderConfigureValidateOnConfigureBadEndpoint(t *testing.T) {
+	clearProviderEnv(t)
+
+	p := New("test")()
+	req := providerConfigureRequest(t, p, map[string]tftypes.Value{
+		"endpoint":              tftypes.NewValue(tftypes.String, "https://127.0.0.1:1"),
+		"username":              tftypes.NewValue(tftypes.String, "user"),
+		"password":              tftypes.NewValue(tftypes.String, "pass"),
+		"timeout":               tftypes.NewValue(tftypes.String, "2s"),
+		"validate_on_configure": tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	var resp provider.ConfigureResponse
+	p.Configure(context.Background(), req, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected configure-time diagnostic for unreachable endpoint")
+	}
+	found := false
+	for _, d := range resp.Diagnostics.Errors() {
+		if d.Summary() == "Unable to connect to MSA" && strings.Contains(d.Detail(), "https://127.0.0.1:1") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected connection diagnostic, got %v", resp.Diagnostics)
+	}
+	if resp.ResourceData != nil || resp.DataSourceData != nil {
+		t.Fatalf("expected no provider data when validation fails")
+	}
+}
+
+func TestProviderConfigureSkipsValidationByDefault(t *testing.T) {
+	clearProviderEnv(t)
+
+	p := New("test")()
+	req := providerConfigureRequest(t, p, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, "https://127.0.0.1:1"),
+		"username": tftypes.NewValue(tftypes.String, "user"),
+		"password": tftypes.NewValue(tftypes.String, "pass"),
+	})
+
+	var resp provider.ConfigureResponse
+	p.Configure(context.Background(), req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected offline-friendly configure, got %v", resp.Diagnostics)
+	}
+	if resp.ResourceData == nil {
+		t.Fatalf("expected client to be configured")
+	}
+}
+
+func TestProviderConfigureReadOnly(t *testing.T) {
+	clearProviderEnv(t)
+	t.Setenv("MSA_READ_ONLY", "true")
+
+	p := New("test")()
+	req := providerConfigureRequest(t, p, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, "https://127.0.0.1:1"),
+		"username": tftypes.NewValue(tftypes.String, "user"),
+		"password": tftypes.NewValue(tftypes.String, "pass"),
+	})
+
+	var resp provider.ConfigureResponse
+	p.Configure(context.Background(), req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected configure diagnostics: %v", resp.Diagnostics)
+	}
+	client, ok := resp.ResourceData.(*msa.Client)
+	if !ok {
+		t.Fatalf("expected an MSA client, got %T", resp.ResourceData)
+	}
+	if _, err := client.Execute(context.Background(), "delete", "volumes", "vol01"); !errors.Is(err, msa.ErrReadOnly) {
+		t.Fatalf("expected delete to be blocked by read_only, got %v", err)
+	}
+}
+
+func TestProviderConfigureDefaultMappingAccess(t *testing.T) {
+	clearProviderEnv(t)
+
+	configure := func(access string) provider.ConfigureResponse {
+		p := New("test")()
+		req := providerConfigureRequest(t, p, map[string]tftypes.Value{
+			"endpoint":               tftypes.NewValue(tftypes.String, "https://127.0.0.1:1"),
+			"username":               tftypes.NewValue(tftypes.String, "user"),
+			"password":               tftypes.NewValue(tftypes.String, "pass"),
+			"default_mapping_access": tftypes.NewValue(tftypes.String, access),
+		})
+		var resp provider.ConfigureResponse
+		p.Configure(context.Background(), req, &resp)
+		return resp
+	}
+
+	resp := configure("NO-ACCESS")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected configure diagnostics: %v", resp.Diagnostics)
+	}
+	if got := resp.ResourceData.(*msa.Client).DefaultMappingAccess(); got != "no-access" {
+		t.Fatalf("expected canonical no-access, got %q", got)
+	}
+
+	resp = configure("write-only")
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected an invalid default_mapping_access to fail configure")
+	}
+}
+
+func TestProviderConfigureCommandLists(t *testing.T) {
+	clearProviderEnv(t)
+	t.Setenv("MSA_DENIED_COMMANDS", "delete host, * host-group")
+
+	p := New("test")()
+	req := providerConfigureRequest(t, p, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, "https://127.0.0.1:1"),
+		"username": tftypes.NewValue(tftypes.String, "user"),
+		"password": tftypes.NewValue(tftypes.String, "pass"),
+		"allowed_commands": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "delete"),
+		}),
+	})
+
+	var resp provider.ConfigureResponse
+	p.Configure(context.Background(), req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected configure diagnostics: %v", resp.Diagnostics)
+	}
+	client := resp.ResourceData.(*msa.Client)
+	for _, parts := range [][]string{{"delete", "hosts", "host-a"}, {"delete", "host-groups", "cluster"}, {"create", "volume", "vol01"}} {
+		if _, err := client.Execute(context.Background(), parts...); !errors.Is(err, msa.ErrCommandNotAllowed) {
+			t.Fatalf("expected %v to be rejected, got %v", parts, err)
+		}
+	}
+}
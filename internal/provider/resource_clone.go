@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -24,8 +27,12 @@ var _ resource.ResourceWithImportState = (*cloneResource)(nil)
 const (
 	cloneCopyConflictETAMaxRetries = 3
 	cloneCopyETASafetyBuffer       = 5 * time.Second
+	cloneCopyProgressPollInterval  = 10 * time.Second
+	cloneCopyProgressStallPolls    = 60
 	cloneRetryPathETA              = "eta"
 	cloneRetryPathNoETA            = "no-eta"
+	cloneNameSuffixMaxAttempts     = 10
+	maxVolumeNameBytes             = 32
 )
 
 var cloneCopyConflictNoETAWaits = []time.Duration{
@@ -45,17 +52,21 @@ type cloneResource struct {
 }
 
 type cloneResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Name            types.String `tfsdk:"name"`
-	SourceSnapshot  types.String `tfsdk:"source_snapshot"`
-	DestinationPool types.String `tfsdk:"destination_pool"`
-	Pool            types.String `tfsdk:"pool"`
-	VDisk           types.String `tfsdk:"vdisk"`
-	DurableID       types.String `tfsdk:"durable_id"`
-	SerialNumber    types.String `tfsdk:"serial_number"`
-	WWID            types.String `tfsdk:"wwid"`
-	SCSIWWN         types.String `tfsdk:"scsi_wwn"`
-	AllowDestroy    types.Bool   `tfsdk:"allow_destroy"`
+	ID              types.String     `tfsdk:"id"`
+	Name            types.String     `tfsdk:"name"`
+	SourceSnapshot  types.String     `tfsdk:"source_snapshot"`
+	DestinationPool types.String     `tfsdk:"destination_pool"`
+	Pool            types.String     `tfsdk:"pool"`
+	VDisk           types.String     `tfsdk:"vdisk"`
+	DurableID       types.String     `tfsdk:"durable_id"`
+	SerialNumber    types.String     `tfsdk:"serial_number"`
+	WWID            types.String     `tfsdk:"wwid"`
+	SCSIWWN         types.String     `tfsdk:"scsi_wwn"`
+	AutoSuffix      types.Bool       `tfsdk:"auto_suffix_on_collision"`
+	VolumeName      types.String     `tfsdk:"volume_name"`
+	AllowDestroy    types.Bool       `tfsdk:"allow_destroy"`
+	AdoptExisting   types.Bool       `tfsdk:"adopt_existing"`
+	Connection      *connectionModel `tfsdk:"connection"`
 }
 
 func (r *cloneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -116,12 +127,34 @@ func (r *cloneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Description: "Host-visible SCSI WWN/NAA identifier reported by the array.",
 				Computed:    true,
 			},
+			"auto_suffix_on_collision": schema.BoolAttribute{
+				Description: "When the destination name is already in use, retry with name-1, name-2, ... (up to 10 attempts) instead of failing.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"volume_name": schema.StringAttribute{
+				Description: "Name of the volume actually created on the array (differs from name when a suffix was applied).",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"allow_destroy": schema.BoolAttribute{
 				Description: "Require explicit opt-in to delete clones.",
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Adopt an existing volume with the same name when it is a standard volume in destination_pool with the size of source_snapshot, instead of copying again. The array does not record where a copy came from, so adoption cannot prove the volume was copied from source_snapshot.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"connection": connectionBlock(),
 		},
 	}
 }
@@ -146,6 +179,10 @@ func (r *cloneResource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, plan.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	var configSource types.String
 	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("source_snapshot"), &configSource)...)
 	if resp.Diagnostics.HasError() {
@@ -184,29 +221,74 @@ func (r *cloneResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	parts := []string{"copy", "volume"}
+	destinationPool := ""
 	if !plan.DestinationPool.IsNull() && !plan.DestinationPool.IsUnknown() {
-		pool := strings.TrimSpace(plan.DestinationPool.ValueString())
-		if pool != "" {
-			parts = append(parts, "destination-pool", pool)
-		}
+		destinationPool = strings.TrimSpace(plan.DestinationPool.ValueString())
 	} else if plan.DestinationPool.IsUnknown() {
 		resp.Diagnostics.AddError("Invalid configuration", "destination_pool must be known")
 		return
 	}
-	parts = append(parts, "name", name, source)
 
-	err = r.executeCloneCopy(ctx, source, name, parts...)
+	existing, outcome, diags := reconcileBeforeCreate(ctx, existingObjectCheck[msa.Volume]{
+		Kind:  "Clone",
+		Name:  name,
+		Adopt: plan.AdoptExisting.ValueBool(),
+		Find: func(ctx context.Context) (*msa.Volume, error) {
+			return r.findVolume(ctx, name, "")
+		},
+		NotFound: errVolumeNotFound,
+		Differences: func(volume *msa.Volume) []string {
+			sourceSize, err := cloneSourceSize(ctx, r.client, source)
+			if err != nil {
+				return []string{fmt.Sprintf("size could not be compared with source_snapshot %q: %v", source, err)}
+			}
+			return cloneSpecDifferences(volume, destinationPool, sourceSize)
+		},
+	})
+	if outcome == reconcileAdopt {
+		state := cloneStateFromModel(plan, existing)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+	if outcome == reconcileConflict && (existing == nil || !plan.AutoSuffix.ValueBool()) {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	// With auto_suffix_on_collision an existing volume that cannot be adopted
+	// is left alone and the copy below picks a suffixed name.
+
+	copyAs := func(candidate string) error {
+		return r.executeCloneCopy(ctx, source, candidate, cloneCopyCommand(destinationPool, candidate, source)...)
+	}
+
+	createdName := name
+	if plan.AutoSuffix.ValueBool() {
+		createdName, err = copyCloneWithSuffix(name, cloneNameSuffixMaxAttempts, copyAs)
+		if createdName != name && err == nil {
+			tflog.Info(ctx, "Clone destination name in use; created with suffix", map[string]any{
+				"requested_name": name,
+				"created_name":   createdName,
+			})
+		}
+	} else {
+		err = copyAs(name)
+	}
 	if err != nil {
-		if isCloneAlreadyExistsError(err) {
-			resp.Diagnostics.AddError("Clone already exists", "Import the clone or choose a different name.")
+		if isObjectLimitError(err) {
+			resp.Diagnostics.AddError(objectLimitDiagnostic(ctx, r.client, "volume", err))
+			return
+		}
+		if isCloneAlreadyExistsError(err) && !plan.AutoSuffix.ValueBool() {
+			resp.Diagnostics.AddError("Clone already exists", "Import the clone, choose a different name, or set auto_suffix_on_collision = true.")
 			return
 		}
 		resp.Diagnostics.AddError("Unable to copy volume", err.Error())
 		return
 	}
 
-	volume, err := r.waitForVolume(ctx, name, "")
+	waitForCopyCompletion(ctx, r.client, source, createdName, cloneCopyProgressPollInterval, cloneCopyProgressStallPolls)
+
+	volume, err := r.waitForVolume(ctx, createdName, "")
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to read clone after create", err.Error())
 		return
@@ -222,15 +304,19 @@ func (r *cloneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
 	}
 
-	name := strings.TrimSpace(state.Name.ValueString())
+	name := cloneLookupName(state)
 	id := strings.TrimSpace(state.ID.ValueString())
 	if name == "" && id == "" {
-		resp.Diagnostics.AddError("Invalid state", "name or id is required")
+		resp.Diagnostics.AddError("Invalid state", "volume_name or id is required")
 		return
 	}
 
@@ -249,7 +335,25 @@ func (r *cloneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *cloneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Update not supported", "Change clone parameters by recreating the resource.")
+	var plan cloneResourceModel
+	var state cloneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(applyConnection(&r.client, plan.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every array-side attribute requires replacement, so only provider-side
+	// flags (allow_destroy, auto_suffix_on_collision, adopt_existing) can
+	// change in place.
+	state.AllowDestroy = plan.AllowDestroy
+	state.AutoSuffix = plan.AutoSuffix
+	state.AdoptExisting = plan.AdoptExisting
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *cloneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -258,62 +362,52 @@ func (r *cloneResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if r.client == nil {
-		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
+	resp.Diagnostics.Append(checkAllowDestroy(state.AllowDestroy, "hpe_msa_clone", state.Name.ValueString())...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	if state.AllowDestroy.IsUnknown() || state.AllowDestroy.IsNull() || !state.AllowDestroy.ValueBool() {
-		resp.Diagnostics.AddError(
-			"Deletion blocked",
-			"Set allow_destroy = true to permit clone deletion.",
-		)
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
 	}
 
 	id := strings.TrimSpace(state.ID.ValueString())
+	name := cloneLookupName(state)
 	target := id
 	if target == "" {
-		target = state.Name.ValueString()
+		target = name
 	}
-	if strings.TrimSpace(target) == "" {
-		resp.Diagnostics.AddError("Invalid state", "clone ID or name is required for deletion")
-		return
-	}
-
-	if guardrail, ok := preDeleteVolumeUsageGuardrail(ctx, r.client, "clone", target, state.Name.ValueString(), id); ok {
-		resp.Diagnostics.AddError(guardrail.summary, guardrail.detail)
+	if target == "" {
+		resp.Diagnostics.AddError("Invalid state", "clone ID or volume_name is required for deletion")
 		return
 	}
 
 	lockOwner := fmt.Sprintf("clone:%s", target)
-	lock, err := acquireDestroyGlobalLock(ctx, lockOwner)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to acquire destroy global lock", err.Error())
-		return
-	}
-	defer func() {
-		if releaseErr := lock.Release(ctx); releaseErr != nil {
-			tflog.Warn(ctx, "release MSA destroy global lock failed", map[string]any{
-				"lock_owner": lockOwner,
-				"error":      releaseErr.Error(),
-			})
+	resp.Diagnostics.Append(withDestroyLock(ctx, lockOwner, func() diag.Diagnostics {
+		var diags diag.Diagnostics
+		if guardrail, ok := preDeleteVolumeUsageGuardrail(ctx, r.client, "clone", target, name, id); ok {
+			diags.AddError(guardrail.summary, guardrail.detail)
+			return diags
 		}
-	}()
 
-	_, err = r.client.Execute(ctx, "delete", "volumes", target)
-	if err != nil {
-		if guardrail, ok := classifyVolumeDeleteError("clone", target, err); ok {
-			resp.Diagnostics.AddError(guardrail.summary, guardrail.detail)
-			return
+		if err := deleteTolerant(ctx, r.client, "delete", "volumes", target); err != nil {
+			if guardrail, ok := classifyVolumeDeleteError("clone", target, err); ok {
+				diags.AddError(guardrail.summary, guardrail.detail)
+				return diags
+			}
+			diags.AddError("Unable to delete clone", err.Error())
 		}
-		resp.Diagnostics.AddError("Unable to delete clone", err.Error())
-		return
-	}
+		return diags
+	})...)
 }
 
 func (r *cloneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	setImportDefaults(ctx, resp, "auto_suffix_on_collision", "allow_destroy", "adopt_existing")
 }
 
 var errCloneSnapshotMissing = errors.New("clone snapshot missing")
@@ -475,7 +569,19 @@ func (r *cloneResource) retryCloneCopyConflict(ctx context.Context, source, targ
 	lastErr := initialErr
 	attempts := 1
 
+	interrupted := func(err error) error {
+		return fmt.Errorf(
+			"copy volume retry interrupted after %d attempt(s); conflict context: %s: %w",
+			attempts,
+			contextState.String(),
+			err,
+		)
+	}
+
 	for {
+		if err := ctx.Err(); err != nil {
+			return interrupted(err)
+		}
 		job, lookupErr := r.client.FindActiveVolumeCopyJob(ctx, source, target)
 		if lookupErr != nil {
 			tflog.Warn(ctx, "Unable to query active volume-copy job during clone retry", map[string]any{
@@ -502,12 +608,7 @@ func (r *cloneResource) retryCloneCopyConflict(ctx context.Context, source, targ
 		tflog.Info(ctx, "Clone copy blocked by active volume-copy; waiting before retry", fields)
 
 		if err := sleepWithContext(ctx, wait); err != nil {
-			return fmt.Errorf(
-				"copy volume retry interrupted after %d attempt(s); conflict context: %s: %w",
-				attempts,
-				contextState.String(),
-				err,
-			)
+			return interrupted(err)
 		}
 
 		_, err := r.client.Execute(ctx, parts...)
@@ -515,6 +616,9 @@ func (r *cloneResource) retryCloneCopyConflict(ctx context.Context, source, targ
 		if err == nil {
 			return nil
 		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return interrupted(ctxErr)
+		}
 
 		lastErr = err
 		if isCloneAlreadyExistsError(err) {
@@ -526,6 +630,134 @@ func (r *cloneResource) retryCloneCopyConflict(ctx context.Context, source, targ
 	}
 }
 
+// volumeCopyJobFinder is the part of the client used to follow copy jobs.
+type volumeCopyJobFinder interface {
+	FindActiveVolumeCopyJob(ctx context.Context, sourceHint, targetHint string) (*msa.VolumeCopyJob, error)
+}
+
+// waitForCopyCompletion follows the array-side copy into target and logs its
+// progress and ETA at Info level on every poll, so long clone creates show
+// movement. It returns once no active job targets the clone, when the job
+// cannot be queried, or when the reported progress has not changed for
+// stallPolls polls; waitForVolume then confirms the clone either way.
+func waitForCopyCompletion(ctx context.Context, finder volumeCopyJobFinder, source, target string, poll time.Duration, stallPolls int) {
+	started := time.Now()
+	lastProgress := ""
+	unchanged := 0
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		job, err := finder.FindActiveVolumeCopyJob(ctx, source, target)
+		if err != nil {
+			tflog.Debug(ctx, "Unable to query volume-copy progress; not waiting for the copy", map[string]any{
+				"clone": target,
+				"error": err.Error(),
+			})
+			return
+		}
+		if job == nil || !strings.EqualFold(strings.TrimSpace(job.Target), target) {
+			return
+		}
+
+		fields := map[string]any{
+			"clone":           target,
+			"source":          source,
+			"elapsed_seconds": int(time.Since(started) / time.Second),
+		}
+		if job.ID != "" {
+			fields["job_id"] = job.ID
+		}
+		progress := "unknown"
+		if job.HasProgress {
+			progress = strconv.FormatFloat(job.Progress, 'f', -1, 64) + "%"
+			fields["progress_percent"] = job.Progress
+		}
+		if job.HasETA {
+			fields["eta"] = job.ETA.String()
+		} else if value := strings.TrimSpace(job.ETARaw); value != "" {
+			fields["eta"] = value
+		}
+		tflog.Info(ctx, "Clone copy in progress", fields)
+
+		if progress == lastProgress {
+			unchanged++
+		} else {
+			unchanged = 0
+			lastProgress = progress
+		}
+		if stallPolls > 0 && unchanged >= stallPolls {
+			tflog.Warn(ctx, "Clone copy progress has not changed; no longer waiting for the copy job", fields)
+			return
+		}
+
+		if err := sleepWithContext(ctx, poll); err != nil {
+			return
+		}
+	}
+}
+
+func cloneCopyCommand(destinationPool, name, source string) []string {
+	parts := []string{"copy", "volume"}
+	if destinationPool != "" {
+		parts = append(parts, "destination-pool", destinationPool)
+	}
+	return append(parts, "name", name, source)
+}
+
+// copyCloneWithSuffix runs copyAs with the requested name and, while the array
+// reports the name as already in use, with name-1, name-2, ... up to
+// maxAttempts suffixes. It returns the name that was created.
+func copyCloneWithSuffix(name string, maxAttempts int, copyAs func(string) error) (string, error) {
+	err := copyAs(name)
+	if err == nil || !isCloneAlreadyExistsError(err) {
+		return name, err
+	}
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		candidate := cloneSuffixedName(name, attempt)
+		err = copyAs(candidate)
+		if err == nil {
+			return candidate, nil
+		}
+		if !isCloneAlreadyExistsError(err) {
+			return candidate, err
+		}
+	}
+
+	return name, fmt.Errorf("no free clone name after %d suffixed attempts (%s-1 .. %s-%d): %w", maxAttempts, name, name, maxAttempts, err)
+}
+
+// cloneSuffixedName appends "-<attempt>" to name, shortening name so the
+// result fits the array's byte limit. The cut steps back to a rune boundary
+// so a multi-byte character is never split.
+func cloneSuffixedName(name string, attempt int) string {
+	suffix := fmt.Sprintf("-%d", attempt)
+	base := name
+	if len(base)+len(suffix) > maxVolumeNameBytes {
+		cut := maxVolumeNameBytes - len(suffix)
+		for cut > 0 && !utf8.RuneStart(base[cut]) {
+			cut--
+		}
+		base = strings.TrimRight(base[:cut], "-")
+	}
+	return base + suffix
+}
+
+func isCloneSuffixedName(requested, actual string) bool {
+	requested = strings.TrimSpace(requested)
+	actual = strings.TrimSpace(actual)
+	if requested == "" || actual == "" {
+		return false
+	}
+	for attempt := 1; attempt <= cloneNameSuffixMaxAttempts; attempt++ {
+		if strings.EqualFold(cloneSuffixedName(requested, attempt), actual) {
+			return true
+		}
+	}
+	return false
+}
+
 func isCloneAlreadyExistsError(err error) bool {
 	var apiErr msa.APIError
 	if !errors.As(err, &apiErr) {
@@ -562,6 +794,22 @@ func sleepWithContext(ctx context.Context, wait time.Duration) error {
 	}
 }
 
+// cloneLookupName returns the array name recorded in volume_name. With
+// auto_suffix_on_collision the configured name can belong to the unrelated
+// volume that caused the collision, so it is never used once a suffix may
+// have been applied.
+func cloneLookupName(state cloneResourceModel) string {
+	if !state.VolumeName.IsNull() && !state.VolumeName.IsUnknown() {
+		if name := strings.TrimSpace(state.VolumeName.ValueString()); name != "" {
+			return name
+		}
+	}
+	if state.AutoSuffix.ValueBool() {
+		return ""
+	}
+	return strings.TrimSpace(state.Name.ValueString())
+}
+
 func (r *cloneResource) findVolume(ctx context.Context, name, id string) (*msa.Volume, error) {
 	response, err := r.client.Execute(ctx, "show", "volumes")
 	if err != nil {
@@ -587,6 +835,9 @@ func (r *cloneResource) findVolume(ctx context.Context, name, id string) (*msa.V
 func (r *cloneResource) waitForVolume(ctx context.Context, name, id string) (*msa.Volume, error) {
 	waits := []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 15 * time.Second, 30 * time.Second}
 	for i, wait := range waits {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		volume, err := r.findVolume(ctx, name, id)
 		if err == nil {
 			return volume, nil
@@ -605,7 +856,15 @@ func (r *cloneResource) waitForVolume(ctx context.Context, name, id string) (*ms
 
 func cloneStateFromModel(model cloneResourceModel, volume *msa.Volume) cloneResourceModel {
 	state := model
-	state.Name = types.StringValue(volume.Name)
+	// Keep the requested name when the clone was created with a collision
+	// suffix so the configuration does not plan a replacement.
+	if model.Name.IsNull() || model.Name.IsUnknown() || !isCloneSuffixedName(model.Name.ValueString(), volume.Name) {
+		state.Name = types.StringValue(volume.Name)
+	}
+	state.VolumeName = types.StringValue(volume.Name)
+	if state.AutoSuffix.IsNull() || state.AutoSuffix.IsUnknown() {
+		state.AutoSuffix = types.BoolValue(false)
+	}
 
 	if volume.PoolName != "" {
 		state.Pool = types.StringValue(volume.PoolName)
@@ -629,3 +888,35 @@ func cloneStateFromModel(model cloneResourceModel, volume *msa.Volume) cloneReso
 
 	return state
 }
+
+// cloneSpecDifferences compares an existing volume with the clone that would
+// be copied: a standard volume (not a snapshot) in destinationPool, when set,
+// with the size in blocks of the source snapshot. The array keeps no record
+// of where a copy came from, so a match cannot prove provenance.
+func cloneSpecDifferences(volume *msa.Volume, destinationPool, sourceSize string) []string {
+	differences := make([]string, 0, 3)
+	if volume.IsSnapshot() {
+		differences = append(differences, "it is a snapshot, not a volume copy")
+	}
+	if !volumeMatchesTarget(volume, destinationPool) {
+		differences = append(differences, fmt.Sprintf("pool is %q, want %q", firstNonEmpty(volume.PoolName, volume.VDiskName), destinationPool))
+	}
+	if strings.TrimSpace(volume.SizeNumeric) != strings.TrimSpace(sourceSize) {
+		differences = append(differences, fmt.Sprintf("size is %s blocks, source_snapshot has %s blocks", firstNonEmpty(volume.SizeNumeric, "unknown"), firstNonEmpty(sourceSize, "unknown")))
+	}
+	return differences
+}
+
+// cloneSourceSize returns the size in blocks of the source snapshot.
+func cloneSourceSize(ctx context.Context, client commandExecutor, source string) (string, error) {
+	response, err := client.Execute(ctx, "show", "snapshots")
+	if err != nil {
+		return "", err
+	}
+	for _, snapshot := range msa.SnapshotsFromResponse(response) {
+		if strings.EqualFold(snapshot.Name, source) && strings.TrimSpace(snapshot.SizeNumeric) != "" {
+			return strings.TrimSpace(snapshot.SizeNumeric), nil
+		}
+	}
+	return "", errSnapshotNotFound
+}
@@ -1,14 +1,19 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 )
 
 func TestResolveCloneSnapshot(t *testing.T) {
@@ -267,3 +272,319 @@ func TestSleepWithContextCancelled(t *testing.T) {
 		t.Fatalf("expected context cancellation, got %v", err)
 	}
 }
+
+func TestCloneSuffixedName(t *testing.T) {
+	cases := []struct {
+		name    string
+		attempt int
+		want    string
+	}{
+		{name: "restore-db", attempt: 1, want: "restore-db-1"},
+		{name: "restore-db", attempt: 10, want: "restore-db-10"},
+		{name: strings.Repeat("a", 32), attempt: 2, want: strings.Repeat("a", 30) + "-2"},
+		{name: strings.Repeat("a", 29) + "-bb", attempt: 3, want: strings.Repeat("a", 29) + "-3"},
+		// "é" is two bytes and straddles the 30-byte cut.
+		{name: strings.Repeat("a", 29) + "ébc", attempt: 2, want: strings.Repeat("a", 29) + "-2"},
+		{name: "sauvegarde-données-" + strings.Repeat("é", 8), attempt: 10, want: "sauvegarde-données-" + strings.Repeat("é", 4) + "-10"},
+	}
+
+	for _, tc := range cases {
+		got := cloneSuffixedName(tc.name, tc.attempt)
+		if got != tc.want {
+			t.Fatalf("cloneSuffixedName(%q, %d): expected %q, got %q", tc.name, tc.attempt, tc.want, got)
+		}
+		if len(got) > maxVolumeNameBytes {
+			t.Fatalf("suffixed name %q exceeds %d bytes", got, maxVolumeNameBytes)
+		}
+		if !utf8.ValidString(got) {
+			t.Fatalf("suffixed name %q is not valid UTF-8", got)
+		}
+		if !isCloneSuffixedName(tc.name, got) {
+			t.Fatalf("expected %q to be recognized as a suffixed %q", got, tc.name)
+		}
+	}
+}
+
+func TestCopyCloneWithSuffix(t *testing.T) {
+	inUse := msa.APIError{Status: msa.Status{Response: "Error: The name is already in use. - name already in use"}}
+
+	t.Run("first name free", func(t *testing.T) {
+		var attempts []string
+		got, err := copyCloneWithSuffix("clone", 3, func(name string) error {
+			attempts = append(attempts, name)
+			return nil
+		})
+		if err != nil || got != "clone" {
+			t.Fatalf("expected clone without error, got %q, %v", got, err)
+		}
+		if len(attempts) != 1 {
+			t.Fatalf("expected one attempt, got %v", attempts)
+		}
+	})
+
+	t.Run("collisions then success", func(t *testing.T) {
+		var attempts []string
+		got, err := copyCloneWithSuffix("clone", 5, func(name string) error {
+			attempts = append(attempts, name)
+			if len(attempts) < 3 {
+				return inUse
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "clone-2" {
+			t.Fatalf("expected clone-2, got %q", got)
+		}
+		if strings.Join(attempts, ",") != "clone,clone-1,clone-2" {
+			t.Fatalf("unexpected attempts %v", attempts)
+		}
+	})
+
+	t.Run("bounded retries", func(t *testing.T) {
+		calls := 0
+		_, err := copyCloneWithSuffix("clone", 3, func(string) error {
+			calls++
+			return inUse
+		})
+		if err == nil {
+			t.Fatalf("expected error after exhausting suffixes")
+		}
+		if !isCloneAlreadyExistsError(err) {
+			t.Fatalf("expected wrapped collision error, got %v", err)
+		}
+		if calls != 4 {
+			t.Fatalf("expected 4 attempts (base + 3 suffixes), got %d", calls)
+		}
+	})
+
+	t.Run("other errors stop immediately", func(t *testing.T) {
+		calls := 0
+		_, err := copyCloneWithSuffix("clone", 3, func(string) error {
+			calls++
+			if calls == 1 {
+				return inUse
+			}
+			return errors.New("pool offline")
+		})
+		if err == nil || err.Error() != "pool offline" {
+			t.Fatalf("expected pool offline error, got %v", err)
+		}
+		if calls != 2 {
+			t.Fatalf("expected 2 attempts, got %d", calls)
+		}
+	})
+}
+
+func TestCloneStateFromModelKeepsRequestedNameForSuffix(t *testing.T) {
+	model := cloneResourceModel{
+		Name:       types.StringValue("clone"),
+		AutoSuffix: types.BoolValue(true),
+	}
+	volume := &msa.Volume{Name: "clone-2", SerialNumber: "SN1"}
+
+	state := cloneStateFromModel(model, volume)
+	if state.Name.ValueString() != "clone" {
+		t.Fatalf("expected requested name to be kept, got %q", state.Name.ValueString())
+	}
+	if state.VolumeName.ValueString() != "clone-2" {
+		t.Fatalf("expected volume_name clone-2, got %q", state.VolumeName.ValueString())
+	}
+
+	imported := cloneStateFromModel(cloneResourceModel{Name: types.StringNull()}, volume)
+	if imported.Name.ValueString() != "clone-2" {
+		t.Fatalf("expected imported name from array, got %q", imported.Name.ValueString())
+	}
+}
+
+func TestCloneSuffixedLookupIgnoresCollidingVolume(t *testing.T) {
+	// "clone" is the unrelated volume that forced the suffix; the clone
+	// itself (clone-2) is gone.
+	server, paths := newMSATestServer(t, func(path string) string {
+		if path == "/api/show/volumes" {
+			return `<RESPONSE VERSION="L100"><OBJECT basetype="volumes" name="volume"><PROPERTY name="volume-name">clone</PROPERTY><PROPERTY name="serial-number">SN-OTHER</PROPERTY></OBJECT></RESPONSE>`
+		}
+		return `<RESPONSE VERSION="L100"></RESPONSE>`
+	})
+	client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	r := &cloneResource{client: client}
+
+	state := resourceState(t, r, map[string]tftypes.Value{
+		"name":                     tftypes.NewValue(tftypes.String, "clone"),
+		"volume_name":              tftypes.NewValue(tftypes.String, "clone-2"),
+		"auto_suffix_on_collision": tftypes.NewValue(tftypes.Bool, true),
+		"allow_destroy":            tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	readResp := resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected read diagnostics: %v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Fatalf("expected the missing clone to be removed from state instead of adopting the colliding volume")
+	}
+
+	deleteResp := resource.DeleteResponse{State: state}
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, &deleteResp)
+	for _, path := range *paths {
+		if strings.HasPrefix(path, "/api/delete/") && path != "/api/delete/volumes/clone-2" {
+			t.Fatalf("expected delete to target clone-2 only, got %v", *paths)
+		}
+	}
+
+	if got := cloneLookupName(cloneResourceModel{Name: types.StringValue("clone"), AutoSuffix: types.BoolValue(true)}); got != "" {
+		t.Fatalf("expected no name fallback with auto suffix, got %q", got)
+	}
+	if got := cloneLookupName(cloneResourceModel{Name: types.StringValue("clone"), AutoSuffix: types.BoolValue(false)}); got != "clone" {
+		t.Fatalf("expected configured name without auto suffix, got %q", got)
+	}
+}
+
+func TestCloneWaitForVolumeCancelled(t *testing.T) {
+	server, _ := newMSATestServer(t, func(string) string {
+		return `<RESPONSE VERSION="L100"></RESPONSE>`
+	})
+	client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	r := &cloneResource{client: client}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err = r.waitForVolume(ctx, "clone-a", "")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context cancellation, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected prompt return after cancellation, took %s", elapsed)
+	}
+}
+
+func TestRetryCloneCopyConflictCancelled(t *testing.T) {
+	server, paths := newMSATestServer(t, func(string) string {
+		return `<RESPONSE VERSION="L100"></RESPONSE>`
+	})
+	client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	r := &cloneResource{client: client}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	conflict := msa.APIError{Status: msa.Status{Response: "The volume is already in a volume copy operation."}}
+	start := time.Now()
+	err = r.retryCloneCopyConflict(ctx, "src", "clone-a", cloneCopyCommand("", "clone-a", "src"), conflict)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context cancellation, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "copy volume retry interrupted") {
+		t.Fatalf("expected interrupted retry error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected prompt return after cancellation, took %s", elapsed)
+	}
+	for _, path := range *paths {
+		if strings.HasPrefix(path, "/api/copy/") {
+			t.Fatalf("copy must not be retried after cancellation, got %v", *paths)
+		}
+	}
+}
+
+type sequenceCopyJobFinder struct {
+	jobs  []*msa.VolumeCopyJob
+	calls int
+}
+
+func (f *sequenceCopyJobFinder) FindActiveVolumeCopyJob(_ context.Context, _, _ string) (*msa.VolumeCopyJob, error) {
+	f.calls++
+	if f.calls > len(f.jobs) {
+		return nil, nil
+	}
+	return f.jobs[f.calls-1], nil
+}
+
+func TestWaitForCopyCompletionLogsProgress(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	finder := &sequenceCopyJobFinder{jobs: []*msa.VolumeCopyJob{
+		{ID: "job-1", Target: "clone-01", Active: true, Progress: 20, HasProgress: true, ETA: 3 * time.Minute, HasETA: true},
+		{ID: "job-1", Target: "clone-01", Active: true, Progress: 75, HasProgress: true, ETARaw: "00:00:45"},
+	}}
+
+	waitForCopyCompletion(ctx, finder, "snap-01", "clone-01", time.Millisecond, 0)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("decode logs: %v", err)
+	}
+	var progress []any
+	var etas []any
+	for _, entry := range entries {
+		if entry["@message"] != "Clone copy in progress" {
+			continue
+		}
+		progress = append(progress, entry["progress_percent"])
+		etas = append(etas, entry["eta"])
+	}
+	if len(progress) != 2 || progress[0] != float64(20) || progress[1] != float64(75) {
+		t.Fatalf("expected progress 20 then 75, got %v", progress)
+	}
+	if etas[0] != "3m0s" || etas[1] != "00:00:45" {
+		t.Fatalf("expected ETAs to be logged, got %v", etas)
+	}
+	if finder.calls != 3 {
+		t.Fatalf("expected polling to stop once the job is gone, got %d polls", finder.calls)
+	}
+}
+
+func TestWaitForCopyCompletionIgnoresOtherJobsAndStalls(t *testing.T) {
+	other := &sequenceCopyJobFinder{jobs: []*msa.VolumeCopyJob{{Target: "someone-else", Active: true}}}
+	waitForCopyCompletion(context.Background(), other, "snap-01", "clone-01", time.Millisecond, 0)
+	if other.calls != 1 {
+		t.Fatalf("expected an unrelated copy job not to be waited on, got %d polls", other.calls)
+	}
+
+	stalled := &sequenceCopyJobFinder{}
+	for i := 0; i < 10; i++ {
+		stalled.jobs = append(stalled.jobs, &msa.VolumeCopyJob{Target: "clone-01", Active: true, Progress: 40, HasProgress: true})
+	}
+	waitForCopyCompletion(context.Background(), stalled, "snap-01", "clone-01", time.Millisecond, 3)
+	if stalled.calls != 4 {
+		t.Fatalf("expected to give up after 3 unchanged polls, got %d polls", stalled.calls)
+	}
+}
+
+func TestCloneSpecDifferences(t *testing.T) {
+	volume := &msa.Volume{Name: "clone01", PoolName: "B", SizeNumeric: "2097152"}
+
+	if diffs := cloneSpecDifferences(volume, "B", "2097152"); len(diffs) != 0 {
+		t.Fatalf("expected a matching copy, got %v", diffs)
+	}
+	diffs := cloneSpecDifferences(volume, "A", "4194304")
+	if len(diffs) != 2 || !strings.Contains(diffs[0], `pool is "B"`) || !strings.Contains(diffs[1], "source_snapshot has 4194304 blocks") {
+		t.Fatalf("expected pool and size differences, got %v", diffs)
+	}
+
+	client := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show snapshots": {response: msa.Response{Objects: []msa.Object{{
+			BaseType:   "snapshots",
+			Properties: []msa.Property{{Name: "name", Value: "snap01"}, {Name: "total-size-numeric", Value: "2097152"}},
+		}}}},
+	}}
+	if size, err := cloneSourceSize(context.Background(), client, "SNAP01"); err != nil || size != "2097152" {
+		t.Fatalf("expected the source snapshot size, got %q (%v)", size, err)
+	}
+	if _, err := cloneSourceSize(context.Background(), client, "snap02"); !errors.Is(err, errSnapshotNotFound) {
+		t.Fatalf("expected a missing source to fail, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -31,17 +32,19 @@ type hostResource struct {
 }
 
 type hostResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Initiators   types.Set    `tfsdk:"initiators"`
-	HostGroup    types.String `tfsdk:"host_group"`
-	Profile      types.String `tfsdk:"profile"`
-	DurableID    types.String `tfsdk:"durable_id"`
-	SerialNumber types.String `tfsdk:"serial_number"`
-	GroupKey     types.String `tfsdk:"group_key"`
-	MemberCount  types.Int64  `tfsdk:"member_count"`
-	Properties   types.Map    `tfsdk:"properties"`
-	AllowDestroy types.Bool   `tfsdk:"allow_destroy"`
+	ID            types.String     `tfsdk:"id"`
+	Name          types.String     `tfsdk:"name"`
+	Initiators    types.Set        `tfsdk:"initiators"`
+	HostGroup     types.String     `tfsdk:"host_group"`
+	Profile       types.String     `tfsdk:"profile"`
+	DurableID     types.String     `tfsdk:"durable_id"`
+	SerialNumber  types.String     `tfsdk:"serial_number"`
+	GroupKey      types.String     `tfsdk:"group_key"`
+	MemberCount   types.Int64      `tfsdk:"member_count"`
+	Properties    types.Map        `tfsdk:"properties"`
+	AllowDestroy  types.Bool       `tfsdk:"allow_destroy"`
+	AdoptExisting types.Bool       `tfsdk:"adopt_existing"`
+	Connection    *connectionModel `tfsdk:"connection"`
 }
 
 func (r *hostResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -82,9 +85,12 @@ func (r *hostResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"profile": schema.StringAttribute{
-				Description: "Host profile (standard, hp-ux, openvms).",
+				Description: "Host profile (standard, hp-ux, openvms). Applied with `set host profile`; read back from the member initiators. Left unmanaged when unset. This is the only per-host presentation setting `set host` accepts; ports are chosen per mapping.",
 				Optional:    true,
 				Computed:    true,
+				Validators: []validator.String{
+					hostProfileValidator{},
+				},
 			},
 			"durable_id": schema.StringAttribute{
 				Description: "Durable ID reported by the array.",
@@ -113,6 +119,15 @@ func (r *hostResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Adopt an existing host with the same name when its initiators (and host_group and profile, when set) match, instead of failing the create.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"connection": connectionBlock(),
 		},
 	}
 }
@@ -137,6 +152,10 @@ func (r *hostResource) Create(ctx context.Context, req resource.CreateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, plan.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
@@ -158,6 +177,32 @@ func (r *hostResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	existing, outcome, diag := reconcileBeforeCreate(ctx, existingObjectCheck[msa.Host]{
+		Kind:  "Host",
+		Name:  name,
+		Adopt: plan.AdoptExisting.ValueBool(),
+		Find: func(ctx context.Context) (*msa.Host, error) {
+			return r.findHost(ctx, name)
+		},
+		NotFound: errHostNotFound,
+		Differences: func(host *msa.Host) []string {
+			return hostSpecDifferences(host, initiators, plan.HostGroup, plan.Profile)
+		},
+	})
+	resp.Diagnostics.Append(diag...)
+	if outcome == reconcileConflict {
+		return
+	}
+	if outcome == reconcileAdopt {
+		state, diag := hostStateFromModel(ctx, plan, existing)
+		resp.Diagnostics.Append(diag...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
 	parts := []string{"create", "host"}
 	if !plan.HostGroup.IsNull() && !plan.HostGroup.IsUnknown() && plan.HostGroup.ValueString() != "" {
 		parts = append(parts, "host-group", plan.HostGroup.ValueString())
@@ -194,6 +239,10 @@ func (r *hostResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
@@ -232,6 +281,10 @@ func (r *hostResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, plan.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
@@ -244,22 +297,7 @@ func (r *hostResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	profile := ""
-	if !plan.Profile.IsNull() && !plan.Profile.IsUnknown() {
-		profile = strings.TrimSpace(plan.Profile.ValueString())
-	}
-
-	updateParts := []string{"set", "host"}
-	changed := false
-	if currentName != newName {
-		updateParts = append(updateParts, "name", newName)
-		changed = true
-	}
-	if profile != "" {
-		updateParts = append(updateParts, "profile", profile)
-		changed = true
-	}
-	updateParts = append(updateParts, currentName)
+	updateParts, changed := hostUpdateCommand(currentName, newName, state.Profile, plan.Profile)
 
 	if changed {
 		if _, err := r.client.Execute(ctx, updateParts...); err != nil {
@@ -289,16 +327,16 @@ func (r *hostResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if r.client == nil {
-		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
+	resp.Diagnostics.Append(checkAllowDestroy(state.AllowDestroy, "hpe_msa_host", state.Name.ValueString())...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	if state.AllowDestroy.IsNull() || !state.AllowDestroy.ValueBool() {
-		resp.Diagnostics.AddError(
-			"Host deletion not permitted",
-			"Set allow_destroy = true to permit host deletion.",
-		)
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
 	}
 
@@ -308,7 +346,7 @@ func (r *hostResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	_, err := r.client.Execute(ctx, "delete", "hosts", name)
+	err := deleteTolerant(ctx, r.client, "delete", "hosts", name)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to delete host", err.Error())
 		return
@@ -317,6 +355,7 @@ func (r *hostResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 
 func (r *hostResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+	setImportDefaults(ctx, resp, "allow_destroy", "adopt_existing")
 }
 
 var errHostNotFound = errors.New("host not found")
@@ -340,6 +379,9 @@ func (r *hostResource) findHost(ctx context.Context, name string) (*msa.Host, er
 func (r *hostResource) waitForHost(ctx context.Context, name string) (*msa.Host, error) {
 	waits := []time.Duration{1 * time.Second, 2 * time.Second, 3 * time.Second}
 	for i, wait := range waits {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		host, err := r.findHost(ctx, name)
 		if err == nil {
 			return host, nil
@@ -379,6 +421,7 @@ func hostStateFromModel(ctx context.Context, model hostResourceModel, host *msa.
 		state.GroupKey = types.StringValue(host.GroupKey)
 	}
 	state.MemberCount = types.Int64Value(int64(host.MemberCount))
+	state.Profile = hostProfileState(model.Profile, host.Profile)
 
 	propsValue, diag := types.MapValueFrom(ctx, types.StringType, host.Properties)
 	if diag.HasError() {
@@ -390,6 +433,86 @@ func hostStateFromModel(ctx context.Context, model hostResourceModel, host *msa.
 	return state, diags
 }
 
+// hostSpecDifferences compares an existing host with the configured
+// initiators (IDs or nicknames) and, when set, its host group and profile.
+func hostSpecDifferences(host *msa.Host, initiators []string, hostGroup, profile types.String) []string {
+	differences := make([]string, 0, 3)
+
+	matched := 0
+	missing := make([]string, 0)
+	for _, configured := range initiators {
+		found := false
+		for _, member := range host.Initiators {
+			if initiatorIDsEqual(member.ID, configured) || strings.EqualFold(strings.TrimSpace(member.Nickname), strings.TrimSpace(configured)) {
+				found = true
+				break
+			}
+		}
+		if found {
+			matched++
+		} else {
+			missing = append(missing, configured)
+		}
+	}
+	if len(missing) > 0 {
+		differences = append(differences, fmt.Sprintf("initiators %s are not members", strings.Join(missing, ", ")))
+	}
+	if members := len(host.Initiators); members > matched {
+		differences = append(differences, fmt.Sprintf("host has %d initiators, want %d", members, len(initiators)))
+	}
+
+	if !hostGroup.IsNull() && !hostGroup.IsUnknown() {
+		if want := strings.TrimSpace(hostGroup.ValueString()); want != "" && !strings.EqualFold(strings.TrimSpace(host.HostGroup), want) {
+			differences = append(differences, fmt.Sprintf("host group is %q, want %q", host.HostGroup, want))
+		}
+	}
+	if !profile.IsNull() && !profile.IsUnknown() {
+		if want := strings.TrimSpace(profile.ValueString()); want != "" && host.Profile != "" && !strings.EqualFold(host.Profile, want) {
+			differences = append(differences, fmt.Sprintf("profile is %q, want %q", host.Profile, want))
+		}
+	}
+	return differences
+}
+
+// hostUpdateCommand builds the `set host` command for a rename and/or profile
+// change. The profile is only sent when it is configured and differs from the
+// value currently reported by the array.
+func hostUpdateCommand(currentName, newName string, currentProfile, desiredProfile types.String) ([]string, bool) {
+	parts := []string{"set", "host"}
+	changed := false
+	if currentName != newName {
+		parts = append(parts, "name", newName)
+		changed = true
+	}
+	if !desiredProfile.IsNull() && !desiredProfile.IsUnknown() {
+		desired := strings.TrimSpace(desiredProfile.ValueString())
+		current := ""
+		if !currentProfile.IsNull() && !currentProfile.IsUnknown() {
+			current = strings.TrimSpace(currentProfile.ValueString())
+		}
+		if desired != "" && !strings.EqualFold(desired, current) {
+			parts = append(parts, "profile", strings.ToLower(desired))
+			changed = true
+		}
+	}
+	parts = append(parts, currentName)
+	return parts, changed
+}
+
+func hostProfileState(configured types.String, reported string) types.String {
+	reported = strings.TrimSpace(reported)
+	if !configured.IsNull() && !configured.IsUnknown() {
+		value := strings.TrimSpace(configured.ValueString())
+		if reported == "" || strings.EqualFold(value, reported) {
+			return configured
+		}
+	}
+	if reported == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(strings.ToLower(reported))
+}
+
 func setToStrings(ctx context.Context, value types.Set) ([]string, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if value.IsNull() || value.IsUnknown() {
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = (*hostGroupResource)(nil)
@@ -30,14 +32,16 @@ type hostGroupResource struct {
 }
 
 type hostGroupResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Hosts        types.Set    `tfsdk:"hosts"`
-	DurableID    types.String `tfsdk:"durable_id"`
-	SerialNumber types.String `tfsdk:"serial_number"`
-	MemberCount  types.Int64  `tfsdk:"member_count"`
-	Properties   types.Map    `tfsdk:"properties"`
-	AllowDestroy types.Bool   `tfsdk:"allow_destroy"`
+	ID            types.String     `tfsdk:"id"`
+	Name          types.String     `tfsdk:"name"`
+	Hosts         types.Set        `tfsdk:"hosts"`
+	DurableID     types.String     `tfsdk:"durable_id"`
+	SerialNumber  types.String     `tfsdk:"serial_number"`
+	MemberCount   types.Int64      `tfsdk:"member_count"`
+	Properties    types.Map        `tfsdk:"properties"`
+	AllowDestroy  types.Bool       `tfsdk:"allow_destroy"`
+	AdoptExisting types.Bool       `tfsdk:"adopt_existing"`
+	Connection    *connectionModel `tfsdk:"connection"`
 }
 
 func (r *hostGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -92,6 +96,15 @@ func (r *hostGroupResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Adopt an existing host group with the same name when its member hosts match, instead of failing the create.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"connection": connectionBlock(),
 		},
 	}
 }
@@ -116,6 +129,10 @@ func (r *hostGroupResource) Create(ctx context.Context, req resource.CreateReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, plan.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
@@ -138,25 +155,36 @@ func (r *hostGroupResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	if existing, err := r.findHostGroupByName(ctx, name); err == nil {
-		resp.Diagnostics.AddError("Host group already exists", "Import the host group or choose a different name.")
-		_ = existing
-		return
-	} else if !errors.Is(err, errHostGroupNotFound) {
-		resp.Diagnostics.AddError("Unable to check existing host groups", err.Error())
+	group, outcome, diag := reconcileBeforeCreate(ctx, existingObjectCheck[msa.HostGroup]{
+		Kind:  "Host group",
+		Name:  name,
+		Adopt: plan.AdoptExisting.ValueBool(),
+		Find: func(ctx context.Context) (*msa.HostGroup, error) {
+			return r.findHostGroupByName(ctx, name)
+		},
+		NotFound: errHostGroupNotFound,
+		Differences: func(group *msa.HostGroup) []string {
+			return hostGroupSpecDifferences(group, hosts)
+		},
+	})
+	resp.Diagnostics.Append(diag...)
+	if outcome == reconcileConflict {
 		return
 	}
 
-	parts := []string{"create", "host-group", "hosts", strings.Join(hosts, ","), name}
-	if _, err := r.client.Execute(ctx, parts...); err != nil {
-		resp.Diagnostics.AddError("Unable to create host group", err.Error())
-		return
-	}
+	if outcome == reconcileCreate {
+		parts := []string{"create", "host-group", "hosts", strings.Join(hosts, ","), name}
+		if _, err := r.client.Execute(ctx, parts...); err != nil {
+			resp.Diagnostics.AddError("Unable to create host group", err.Error())
+			return
+		}
 
-	group, err := r.waitForHostGroup(ctx, name)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to read host group after create", err.Error())
-		return
+		var err error
+		group, err = r.waitForHostGroup(ctx, name)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read host group after create", err.Error())
+			return
+		}
 	}
 
 	state, diag := hostGroupStateFromModel(ctx, plan, group)
@@ -173,6 +201,10 @@ func (r *hostGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
@@ -200,6 +232,13 @@ func (r *hostGroupResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// Imported before ImportState set the defaults.
+	if newState.AllowDestroy.IsNull() {
+		newState.AllowDestroy = types.BoolValue(false)
+	}
+	if newState.AdoptExisting.IsNull() {
+		newState.AdoptExisting = types.BoolValue(false)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
 }
@@ -212,6 +251,10 @@ func (r *hostGroupResource) Update(ctx context.Context, req resource.UpdateReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, plan.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
@@ -262,7 +305,8 @@ func (r *hostGroupResource) Update(ctx context.Context, req resource.UpdateReque
 	if len(addHosts) > 0 {
 		parts := []string{"add", "host-group-members", "hosts", strings.Join(addHosts, ","), currentName}
 		if _, err := r.client.Execute(ctx, parts...); err != nil {
-			resp.Diagnostics.AddError("Unable to add host group members", err.Error())
+			resp.Diagnostics.AddError("Unable to add host group members", hostGroupMemberFailureDetail(addHosts, err))
+			r.saveMembershipAfterFailure(ctx, resp, plan, currentName, currentID)
 			return
 		}
 		group, err = r.findHostGroup(ctx, currentName, currentID)
@@ -283,7 +327,8 @@ func (r *hostGroupResource) Update(ctx context.Context, req resource.UpdateReque
 		}
 		parts := []string{"remove", "host-group-members", "hosts", strings.Join(removeHosts, ","), currentName}
 		if _, err := r.client.Execute(ctx, parts...); err != nil {
-			resp.Diagnostics.AddError("Unable to remove host group members", err.Error())
+			resp.Diagnostics.AddError("Unable to remove host group members", hostGroupMemberFailureDetail(removeHosts, err))
+			r.saveMembershipAfterFailure(ctx, resp, plan, currentName, currentID)
 			return
 		}
 	}
@@ -303,22 +348,91 @@ func (r *hostGroupResource) Update(ctx context.Context, req resource.UpdateReque
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
 }
 
+// saveMembershipAfterFailure re-reads the group after a failed member
+// operation and stores the membership the array actually holds, so state
+// neither claims rejected hosts nor forgets hosts that were applied.
+func (r *hostGroupResource) saveMembershipAfterFailure(ctx context.Context, resp *resource.UpdateResponse, plan hostGroupResourceModel, name, id string) {
+	group, err := r.findHostGroup(ctx, name, id)
+	if err != nil {
+		tflog.Warn(ctx, "unable to re-read host group after failed member update", map[string]any{
+			"host_group": name,
+			"error":      err.Error(),
+		})
+		return
+	}
+
+	newState, diags := hostGroupStateFromModel(ctx, plan, group)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+// hostGroupMemberFailureDetail names the hosts a bulk member command
+// rejected. A partial failure reports one status per host, so each failing
+// status is attributed to the requested host it mentions.
+func hostGroupMemberFailureDetail(hosts []string, err error) string {
+	var partial msa.PartialFailureError
+	if !errors.As(err, &partial) {
+		return err.Error()
+	}
+
+	failed := make([]string, 0)
+	unattributed := make([]string, 0)
+	for _, status := range partial.Failed() {
+		message := strings.TrimSpace(status.Response)
+		host, ok := hostMentionedIn(hosts, message)
+		if !ok {
+			if message != "" {
+				unattributed = append(unattributed, message)
+			}
+			continue
+		}
+		failed = append(failed, fmt.Sprintf("%s (%s)", host, message))
+	}
+
+	var detail strings.Builder
+	fmt.Fprintf(&detail, "The array applied %d of %d status item(s).", len(partial.Succeeded()), len(partial.Statuses))
+	if len(failed) > 0 {
+		fmt.Fprintf(&detail, " Rejected hosts: %s.", strings.Join(failed, ", "))
+	}
+	if len(unattributed) > 0 {
+		fmt.Fprintf(&detail, " Other errors: %s.", strings.Join(unattributed, "; "))
+	}
+	detail.WriteString(" State reflects the membership reported by the array; fix the rejected hosts and apply again.")
+	return detail.String()
+}
+
+// hostMentionedIn returns the longest requested host name contained in
+// message so "host1" does not claim a status about "host10".
+func hostMentionedIn(hosts []string, message string) (string, bool) {
+	lower := strings.ToLower(message)
+	best := ""
+	for _, host := range hosts {
+		if host != "" && strings.Contains(lower, strings.ToLower(host)) && len(host) > len(best) {
+			best = host
+		}
+	}
+	return best, best != ""
+}
+
 func (r *hostGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state hostGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if r.client == nil {
-		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
+	resp.Diagnostics.Append(checkAllowDestroy(state.AllowDestroy, "hpe_msa_host_group", state.Name.ValueString())...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	if state.AllowDestroy.IsNull() || !state.AllowDestroy.ValueBool() {
-		resp.Diagnostics.AddError(
-			"Host group deletion not permitted",
-			"Set allow_destroy = true to permit host group deletion.",
-		)
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
 	}
 
@@ -339,14 +453,19 @@ func (r *hostGroupResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	if _, err := r.client.Execute(ctx, "delete", "host-groups", name); err != nil {
+	if err := deleteTolerant(ctx, r.client, "delete", "host-groups", name); err != nil {
 		resp.Diagnostics.AddError("Unable to delete host group", err.Error())
 		return
 	}
 }
 
+// ImportState only records the name; Read fills hosts, member_count and
+// properties from the array. allow_destroy and adopt_existing are set to
+// their schema defaults so a configuration matching the array plans no
+// changes.
 func (r *hostGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+	setImportDefaults(ctx, resp, "allow_destroy", "adopt_existing")
 }
 
 var errHostGroupNotFound = errors.New("host group not found")
@@ -408,6 +527,9 @@ func (r *hostGroupResource) findHostGroupByName(ctx context.Context, name string
 func (r *hostGroupResource) waitForHostGroup(ctx context.Context, name string) (*msa.HostGroup, error) {
 	waits := []time.Duration{1 * time.Second, 2 * time.Second, 3 * time.Second}
 	for i, wait := range waits {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		group, err := r.findHostGroupByName(ctx, name)
 		if err == nil {
 			return group, nil
@@ -473,6 +595,20 @@ func hostNames(hosts []msa.Host) []string {
 	return values
 }
 
+// hostGroupSpecDifferences compares the members of an existing host group
+// with the configured hosts.
+func hostGroupSpecDifferences(group *msa.HostGroup, hosts []string) []string {
+	toAdd, toRemove := diffHostGroupMembers(hosts, hostNames(group.Hosts))
+	differences := make([]string, 0, 2)
+	if len(toAdd) > 0 {
+		differences = append(differences, fmt.Sprintf("hosts %s are not members", strings.Join(toAdd, ", ")))
+	}
+	if len(toRemove) > 0 {
+		differences = append(differences, fmt.Sprintf("hosts %s are unexpected members", strings.Join(toRemove, ", ")))
+	}
+	return differences
+}
+
 func diffHostGroupMembers(desired []string, actual []string) ([]string, []string) {
 	desiredMap, desiredOrder := normalizedNameMap(desired)
 	actualMap, actualOrder := normalizedNameMap(actual)
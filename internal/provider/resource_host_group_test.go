@@ -1,6 +1,18 @@
 package provider
 
-import "testing"
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
 
 func TestDiffHostGroupMembers(t *testing.T) {
 	add, remove := diffHostGroupMembers(
@@ -64,3 +76,152 @@ func TestUniqueHostNames(t *testing.T) {
 		t.Fatalf("unexpected unique list order: %v", unique)
 	}
 }
+
+func TestHostGroupMemberFailureDetail(t *testing.T) {
+	err := msa.PartialFailureError{Statuses: []msa.Status{
+		{ResponseType: "Success", Response: "Host HostB was added to host group Group1."},
+		{ResponseType: "Error", ResponseTypeNumeric: 1, Response: "Error: The host HostC10 is already a member of another host group.", ReturnCode: -10033},
+		{ResponseType: "Error", ResponseTypeNumeric: 1, Response: "Error: Command failed.", ReturnCode: -1},
+	}}
+
+	detail := hostGroupMemberFailureDetail([]string{"HostB", "HostC", "HostC10"}, err)
+	if !strings.Contains(detail, "applied 1 of 3") {
+		t.Fatalf("expected applied count, got %q", detail)
+	}
+	if !strings.Contains(detail, "Rejected hosts: HostC10 (") {
+		t.Fatalf("expected HostC10 to be attributed, got %q", detail)
+	}
+	if !strings.Contains(detail, "Other errors: Error: Command failed.") {
+		t.Fatalf("expected unattributed status to be listed, got %q", detail)
+	}
+}
+
+func TestHostGroupUpdatePartialMemberAddReflectsArray(t *testing.T) {
+	var added atomic.Bool
+	host := func(name string) string {
+		return `<OBJECT basetype="host" name="host"><PROPERTY name="name">` + name + `</PROPERTY></OBJECT>`
+	}
+	server, _ := newMSATestServer(t, func(path string) string {
+		switch {
+		case strings.HasPrefix(path, "/api/add/host-group-members/"):
+			added.Store(true)
+			return `<RESPONSE VERSION="L100">` +
+				`<OBJECT basetype="status" name="status"><PROPERTY name="response-type">Success</PROPERTY><PROPERTY name="response-type-numeric">0</PROPERTY><PROPERTY name="response">Host HostB was added to host group Group1.</PROPERTY><PROPERTY name="return-code">0</PROPERTY></OBJECT>` +
+				`<OBJECT basetype="status" name="status"><PROPERTY name="response-type">Error</PROPERTY><PROPERTY name="response-type-numeric">1</PROPERTY><PROPERTY name="response">Error: The host HostC is already a member of another host group.</PROPERTY><PROPERTY name="return-code">-10033</PROPERTY></OBJECT>` +
+				`</RESPONSE>`
+		case path == "/api/show/host-groups":
+			members := host("HostA")
+			if added.Load() {
+				members += host("HostB")
+			}
+			return `<RESPONSE VERSION="L100"><OBJECT basetype="host-group" name="host-group"><PROPERTY name="name">Group1</PROPERTY><PROPERTY name="serial-number">SN-G1</PROPERTY>` + members + `</OBJECT></RESPONSE>`
+		default:
+			return `<RESPONSE VERSION="L100"></RESPONSE>`
+		}
+	})
+	client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	r := &hostGroupResource{client: client}
+	hostSet := func(names ...string) tftypes.Value {
+		values := make([]tftypes.Value, 0, len(names))
+		for _, name := range names {
+			values = append(values, tftypes.NewValue(tftypes.String, name))
+		}
+		return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, values)
+	}
+	state := resourceState(t, r, map[string]tftypes.Value{
+		"id":    tftypes.NewValue(tftypes.String, "SN-G1"),
+		"name":  tftypes.NewValue(tftypes.String, "Group1"),
+		"hosts": hostSet("HostA"),
+	})
+	planned := resourceState(t, r, map[string]tftypes.Value{
+		"id":    tftypes.NewValue(tftypes.String, "SN-G1"),
+		"name":  tftypes.NewValue(tftypes.String, "Group1"),
+		"hosts": hostSet("HostA", "HostB", "HostC"),
+	})
+	plan := tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}
+
+	resp := resource.UpdateResponse{State: state}
+	r.Update(context.Background(), resource.UpdateRequest{Plan: plan, State: state}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected partial failure to be reported")
+	}
+	if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "HostC (") {
+		t.Fatalf("expected HostC to be named, got %q", detail)
+	}
+
+	var got hostGroupResourceModel
+	resp.Diagnostics = nil
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &got)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("read state: %v", resp.Diagnostics)
+	}
+	var hosts []string
+	resp.Diagnostics.Append(got.Hosts.ElementsAs(context.Background(), &hosts, false)...)
+	sort.Strings(hosts)
+	if strings.Join(hosts, ",") != "HostA,HostB" {
+		t.Fatalf("expected state to hold the array membership HostA,HostB, got %v", hosts)
+	}
+}
+
+func TestHostGroupImportPopulatesMembersForEmptyPlan(t *testing.T) {
+	server, _ := newMSATestServer(t, func(path string) string {
+		if path != "/api/show/host-groups" {
+			return `<RESPONSE VERSION="L100"></RESPONSE>`
+		}
+		return `<RESPONSE VERSION="L100"><OBJECT basetype="host-group" name="host-group">` +
+			`<PROPERTY name="name">Group1</PROPERTY><PROPERTY name="serial-number">SN-G1</PROPERTY><PROPERTY name="durable-id">HG0</PROPERTY><PROPERTY name="member-count">2</PROPERTY>` +
+			`<OBJECT basetype="host" name="host"><PROPERTY name="name">HostA</PROPERTY></OBJECT>` +
+			`<OBJECT basetype="host" name="host"><PROPERTY name="name">HostB</PROPERTY></OBJECT>` +
+			`</OBJECT></RESPONSE>`
+	})
+	client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	ctx := context.Background()
+	r := &hostGroupResource{client: client}
+
+	importResp := resource.ImportStateResponse{State: resourceState(t, r, nil)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "Group1"}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("import: %v", importResp.Diagnostics)
+	}
+
+	readResp := resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read after import: %v", readResp.Diagnostics)
+	}
+
+	var got hostGroupResourceModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &got)...)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("state: %v", readResp.Diagnostics)
+	}
+
+	// The configuration `name = "Group1"`, `hosts = ["HostB", "HostA"]` with
+	// allow_destroy and adopt_existing left at their defaults must match the imported state.
+	configHosts, diags := types.SetValueFrom(ctx, types.StringType, []string{"HostB", "HostA"})
+	if diags.HasError() {
+		t.Fatalf("config hosts: %v", diags)
+	}
+	if !got.Hosts.Equal(configHosts) {
+		t.Fatalf("expected hosts %v, got %v", configHosts, got.Hosts)
+	}
+	if got.Name.ValueString() != "Group1" || got.ID.ValueString() != "SN-G1" {
+		t.Fatalf("unexpected identity %v/%v", got.Name, got.ID)
+	}
+	if got.MemberCount.ValueInt64() != 2 || got.Properties.IsNull() || len(got.Properties.Elements()) == 0 {
+		t.Fatalf("expected member_count and properties to be populated, got %v/%v", got.MemberCount, got.Properties)
+	}
+	if got.AllowDestroy.IsNull() || got.AllowDestroy.ValueBool() {
+		t.Fatalf("expected allow_destroy to match its default, got %v", got.AllowDestroy)
+	}
+	if got.AdoptExisting.IsNull() || got.AdoptExisting.ValueBool() {
+		t.Fatalf("expected adopt_existing to match its default, got %v", got.AdoptExisting)
+	}
+}
@@ -27,9 +27,10 @@ type hostInitiatorResource struct {
 }
 
 type hostInitiatorResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	HostName    types.String `tfsdk:"host_name"`
-	InitiatorID types.String `tfsdk:"initiator_id"`
+	ID          types.String     `tfsdk:"id"`
+	HostName    types.String     `tfsdk:"host_name"`
+	InitiatorID types.String     `tfsdk:"initiator_id"`
+	Connection  *connectionModel `tfsdk:"connection"`
 }
 
 func (r *hostInitiatorResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -61,6 +62,9 @@ func (r *hostInitiatorResource) Schema(_ context.Context, _ resource.SchemaReque
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"connection": connectionBlock(),
+		},
 	}
 }
 
@@ -84,6 +88,10 @@ func (r *hostInitiatorResource) Create(ctx context.Context, req resource.CreateR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, plan.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
@@ -112,6 +120,10 @@ func (r *hostInitiatorResource) Read(ctx context.Context, req resource.ReadReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
@@ -165,6 +177,10 @@ func (r *hostInitiatorResource) Delete(ctx context.Context, req resource.DeleteR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestHostUpdateCommand(t *testing.T) {
+	testCases := []struct {
+		name        string
+		currentName string
+		newName     string
+		current     types.String
+		desired     types.String
+		want        string
+		wantChanged bool
+	}{
+		{
+			name:        "rename only",
+			currentName: "host-a",
+			newName:     "host-b",
+			current:     types.StringValue("standard"),
+			desired:     types.StringUnknown(),
+			want:        "set host name host-b host-a",
+			wantChanged: true,
+		},
+		{
+			name:        "profile change",
+			currentName: "host-a",
+			newName:     "host-a",
+			current:     types.StringValue("standard"),
+			desired:     types.StringValue("HP-UX"),
+			want:        "set host profile hp-ux host-a",
+			wantChanged: true,
+		},
+		{
+			name:        "profile unchanged ignoring case",
+			currentName: "host-a",
+			newName:     "host-a",
+			current:     types.StringValue("Standard"),
+			desired:     types.StringValue("standard"),
+			want:        "set host host-a",
+			wantChanged: false,
+		},
+		{
+			name:        "profile unmanaged",
+			currentName: "host-a",
+			newName:     "host-a",
+			current:     types.StringValue("standard"),
+			desired:     types.StringNull(),
+			want:        "set host host-a",
+			wantChanged: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parts, changed := hostUpdateCommand(tc.currentName, tc.newName, tc.current, tc.desired)
+			if changed != tc.wantChanged {
+				t.Fatalf("expected changed=%v, got %v", tc.wantChanged, changed)
+			}
+			if got := strings.Join(parts, " "); got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestHostProfileState(t *testing.T) {
+	testCases := []struct {
+		name       string
+		configured types.String
+		reported   string
+		want       types.String
+	}{
+		{name: "unset reads back array value", configured: types.StringUnknown(), reported: "Standard", want: types.StringValue("standard")},
+		{name: "configured matches keeps casing", configured: types.StringValue("HP-UX"), reported: "hp-ux", want: types.StringValue("HP-UX")},
+		{name: "drift surfaces array value", configured: types.StringValue("openvms"), reported: "Standard", want: types.StringValue("standard")},
+		{name: "unreported keeps configured", configured: types.StringValue("standard"), reported: "", want: types.StringValue("standard")},
+		{name: "unreported and unset is null", configured: types.StringNull(), reported: "", want: types.StringNull()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := hostProfileState(tc.configured, tc.reported)
+			if !got.Equal(tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
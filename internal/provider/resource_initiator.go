@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
@@ -29,14 +30,16 @@ type initiatorResource struct {
 }
 
 type initiatorResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	InitiatorID  types.String `tfsdk:"initiator_id"`
-	Nickname     types.String `tfsdk:"nickname"`
-	Profile      types.String `tfsdk:"profile"`
-	HostID       types.String `tfsdk:"host_id"`
-	HostKey      types.String `tfsdk:"host_key"`
-	Properties   types.Map    `tfsdk:"properties"`
-	AllowDestroy types.Bool   `tfsdk:"allow_destroy"`
+	ID                     types.String     `tfsdk:"id"`
+	InitiatorID            types.String     `tfsdk:"initiator_id"`
+	Nickname               types.String     `tfsdk:"nickname"`
+	Profile                types.String     `tfsdk:"profile"`
+	HostID                 types.String     `tfsdk:"host_id"`
+	HostKey                types.String     `tfsdk:"host_key"`
+	Properties             types.Map        `tfsdk:"properties"`
+	AllowDestroy           types.Bool       `tfsdk:"allow_destroy"`
+	AllowDuplicateNickname types.Bool       `tfsdk:"allow_duplicate_nickname"`
+	Connection             *connectionModel `tfsdk:"connection"`
 }
 
 func (r *initiatorResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -88,6 +91,15 @@ func (r *initiatorResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"allow_duplicate_nickname": schema.BoolAttribute{
+				Description: "Skip the check that no other initiator already uses the nickname.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"connection": connectionBlock(),
 		},
 	}
 }
@@ -112,6 +124,10 @@ func (r *initiatorResource) Create(ctx context.Context, req resource.CreateReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, plan.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
@@ -124,6 +140,16 @@ func (r *initiatorResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	// `set initiator` is idempotent for the initiator's own nickname and also
+	// corrects its profile, so only another initiator holding the nickname
+	// blocks the create; nothing is ever adopted.
+	if !plan.AllowDuplicateNickname.ValueBool() {
+		resp.Diagnostics.Append(r.checkNicknameAvailable(ctx, initID, nickname)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	if err := r.setInitiator(ctx, initID, nickname, plan.Profile); err != nil {
 		resp.Diagnostics.AddError("Unable to set initiator", err.Error())
 		return
@@ -150,6 +176,10 @@ func (r *initiatorResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
@@ -189,6 +219,10 @@ func (r *initiatorResource) Update(ctx context.Context, req resource.UpdateReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, plan.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
@@ -201,6 +235,13 @@ func (r *initiatorResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	if !plan.AllowDuplicateNickname.ValueBool() {
+		resp.Diagnostics.Append(r.checkNicknameAvailable(ctx, initID, nickname)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	if err := r.setInitiator(ctx, initID, nickname, plan.Profile); err != nil {
 		resp.Diagnostics.AddError("Unable to update initiator", err.Error())
 		return
@@ -227,16 +268,16 @@ func (r *initiatorResource) Delete(ctx context.Context, req resource.DeleteReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if r.client == nil {
-		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
+	resp.Diagnostics.Append(checkAllowDestroy(state.AllowDestroy, "hpe_msa_initiator", firstNonEmpty(state.Nickname.ValueString(), state.InitiatorID.ValueString()))...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	if state.AllowDestroy.IsNull() || !state.AllowDestroy.ValueBool() {
-		resp.Diagnostics.AddError(
-			"Initiator deletion not permitted",
-			"Set allow_destroy = true to permit initiator nickname deletion.",
-		)
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
 	}
 
@@ -252,7 +293,7 @@ func (r *initiatorResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	_, err := r.client.Execute(ctx, "delete", "initiator-nickname", initID)
+	err := deleteTolerant(ctx, r.client, "delete", "initiator-nickname", initID)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to delete initiator nickname", err.Error())
 		return
@@ -261,6 +302,7 @@ func (r *initiatorResource) Delete(ctx context.Context, req resource.DeleteReque
 
 func (r *initiatorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("initiator_id"), req.ID)...)
+	setImportDefaults(ctx, resp, "allow_destroy", "allow_duplicate_nickname")
 }
 
 var errInitiatorNotFound = errors.New("initiator not found")
@@ -285,6 +327,57 @@ func (r *initiatorResource) findInitiator(ctx context.Context, id, nickname stri
 	return nil, errInitiatorNotFound
 }
 
+// checkNicknameAvailable fails when another initiator ID already carries the
+// nickname. The array does not consistently reject duplicates, and a shared
+// nickname makes nickname-based host member and mapping resolution ambiguous.
+// Create and Update both use it, so the conflict reads the same in either.
+func (r *initiatorResource) checkNicknameAvailable(ctx context.Context, id, nickname string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	owner, err := r.findOtherNicknameOwner(ctx, id, nickname)
+	if errors.Is(err, errInitiatorNotFound) {
+		return diags
+	}
+	if err != nil {
+		diags.AddError("Unable to check initiator nickname", fmt.Sprintf("Unable to list initiators to check nickname uniqueness (set allow_duplicate_nickname = true to skip): %s", err))
+		return diags
+	}
+	diags.AddError("Initiator nickname already in use", fmt.Sprintf("Nickname %q is already used by initiator %s; choose another nickname or set allow_duplicate_nickname = true.", nickname, owner.ID))
+	return diags
+}
+
+// findOtherNicknameOwner returns an initiator other than id that already
+// carries the nickname.
+func (r *initiatorResource) findOtherNicknameOwner(ctx context.Context, id, nickname string) (*msa.Initiator, error) {
+	response, err := r.client.Execute(ctx, "show", "initiators")
+	if err != nil {
+		return nil, err
+	}
+	if owner := nicknameOwner(msa.InitiatorsFromResponse(response), id, nickname); owner != nil {
+		return owner, nil
+	}
+	return nil, errInitiatorNotFound
+}
+
+// nicknameOwner returns the first initiator other than id whose nickname
+// matches case-insensitively.
+func nicknameOwner(initiators []msa.Initiator, id, nickname string) *msa.Initiator {
+	nickname = strings.TrimSpace(nickname)
+	if nickname == "" {
+		return nil
+	}
+	for i := range initiators {
+		initiator := &initiators[i]
+		if initiator.ID == "" || !strings.EqualFold(strings.TrimSpace(initiator.Nickname), nickname) {
+			continue
+		}
+		if initiatorIDsEqual(initiator.ID, id) {
+			continue
+		}
+		return initiator
+	}
+	return nil
+}
+
 func (r *initiatorResource) setInitiator(ctx context.Context, id, nickname string, profile types.String) error {
 	parts := []string{"set", "initiator", "id", id, "nickname", nickname}
 	if !profile.IsNull() && !profile.IsUnknown() && strings.TrimSpace(profile.ValueString()) != "" {
@@ -348,3 +441,18 @@ func initiatorLookupID(state initiatorResourceModel) string {
 	}
 	return strings.TrimSpace(state.InitiatorID.ValueString())
 }
+
+// initiatorIDsEqual compares initiator IDs case-insensitively, ignoring the
+// ":"/"-" separators users commonly write into WWPNs.
+func initiatorIDsEqual(a, b string) bool {
+	a = strings.TrimSpace(a)
+	b = strings.TrimSpace(b)
+	if strings.EqualFold(a, b) {
+		return true
+	}
+	if strings.Contains(strings.ToLower(a), "iqn.") || strings.Contains(strings.ToLower(b), "iqn.") {
+		return false
+	}
+	strip := strings.NewReplacer(":", "", "-", "")
+	return strings.EqualFold(strip.Replace(a), strip.Replace(b))
+}
@@ -2,10 +2,14 @@ package provider
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestInitiatorStateFromModelPreservePlan(t *testing.T) {
@@ -128,3 +132,124 @@ func TestInitiatorLookupIDPrefersCanonicalID(t *testing.T) {
 		t.Fatalf("unexpected fallback id: %s", got)
 	}
 }
+
+func TestNicknameOwner(t *testing.T) {
+	initiators := []msa.Initiator{
+		{ID: "20000000000000c1", Nickname: "esx-a-port0"},
+		{ID: "20000000000000c2", Nickname: "esx-a-port1"},
+		{ID: "iqn.1998-01.com.vmware:esx-b", Nickname: "esx-b"},
+	}
+
+	if owner := nicknameOwner(initiators, "20000000000000c3", "new-nick"); owner != nil {
+		t.Fatalf("expected unused nickname to pass, got %+v", owner)
+	}
+	if owner := nicknameOwner(initiators, "20:00:00:00:00:00:00:C1", "ESX-A-PORT0"); owner != nil {
+		t.Fatalf("expected the initiator's own nickname to pass, got %+v", owner)
+	}
+	if owner := nicknameOwner(initiators, "20000000000000c3", "Esx-A-Port1"); owner == nil || owner.ID != "20000000000000c2" {
+		t.Fatalf("expected the other initiator to own the nickname, got %+v", owner)
+	}
+	if owner := nicknameOwner(initiators, "iqn.1998-01.com.vmware:esx-c", "esx-b"); owner == nil {
+		t.Fatalf("expected conflict for iSCSI nickname reuse")
+	}
+}
+
+func TestInitiatorCreateReconcilesNickname(t *testing.T) {
+	server, paths := newMSATestServer(t, func(path string) string {
+		if path == "/api/show/initiators" {
+			return `<RESPONSE VERSION="L100">` +
+				`<OBJECT basetype="initiator" name="initiator"><PROPERTY name="id">20000000000000c1</PROPERTY><PROPERTY name="nickname">esx-a-port0</PROPERTY><PROPERTY name="profile">hp-ux</PROPERTY></OBJECT>` +
+				`<OBJECT basetype="initiator" name="initiator"><PROPERTY name="id">20000000000000c2</PROPERTY><PROPERTY name="nickname">esx-a-port1</PROPERTY><PROPERTY name="profile">standard</PROPERTY></OBJECT>` +
+				`</RESPONSE>`
+		}
+		return `<RESPONSE VERSION="L100"></RESPONSE>`
+	})
+	client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	r := &initiatorResource{client: client}
+
+	create := func(id, nickname string, allowDuplicate bool) resource.CreateResponse {
+		*paths = nil
+		planned := resourceState(t, r, map[string]tftypes.Value{
+			"initiator_id":             tftypes.NewValue(tftypes.String, id),
+			"nickname":                 tftypes.NewValue(tftypes.String, nickname),
+			"profile":                  tftypes.NewValue(tftypes.String, "standard"),
+			"allow_duplicate_nickname": tftypes.NewValue(tftypes.Bool, allowDuplicate),
+		})
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: planned.Schema}}
+		r.Create(context.Background(), resource.CreateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}}, &resp)
+		return resp
+	}
+	setCommands := func() []string {
+		var sets []string
+		for _, path := range *paths {
+			if strings.HasPrefix(path, "/api/set/initiator/") {
+				sets = append(sets, path)
+			}
+		}
+		return sets
+	}
+
+	t.Run("re-sets its own nickname and corrects the profile", func(t *testing.T) {
+		resp := create("20:00:00:00:00:00:00:C1", "esx-a-port0", false)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("expected an idempotent create, got %v", resp.Diagnostics)
+		}
+		if sets := setCommands(); len(sets) != 1 || !strings.Contains(sets[0], "/profile/standard") {
+			t.Fatalf("expected one set initiator with the profile, got %v", *paths)
+		}
+	})
+
+	t.Run("rejects a nickname held by another initiator", func(t *testing.T) {
+		resp := create("20000000000000c3", "esx-a-port1", false)
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected a conflict, got %v", resp.Diagnostics)
+		}
+		createErr := resp.Diagnostics.Errors()[0]
+		if createErr.Summary() != "Initiator nickname already in use" || !strings.Contains(createErr.Detail(), "20000000000000c2") || !strings.Contains(createErr.Detail(), "allow_duplicate_nickname") {
+			t.Fatalf("expected a conflict naming the owner and the opt-out, got %v", resp.Diagnostics)
+		}
+		if sets := setCommands(); len(sets) != 0 {
+			t.Fatalf("expected no set initiator on conflict, got %v", sets)
+		}
+
+		// Renaming an existing initiator onto the same nickname reports the
+		// identical diagnostic.
+		*paths = nil
+		prior := resourceState(t, r, map[string]tftypes.Value{
+			"id":                       tftypes.NewValue(tftypes.String, "20000000000000c3"),
+			"initiator_id":             tftypes.NewValue(tftypes.String, "20000000000000c3"),
+			"nickname":                 tftypes.NewValue(tftypes.String, "esx-a-port2"),
+			"profile":                  tftypes.NewValue(tftypes.String, "standard"),
+			"allow_duplicate_nickname": tftypes.NewValue(tftypes.Bool, false),
+		})
+		planned := resourceState(t, r, map[string]tftypes.Value{
+			"id":                       tftypes.NewValue(tftypes.String, "20000000000000c3"),
+			"initiator_id":             tftypes.NewValue(tftypes.String, "20000000000000c3"),
+			"nickname":                 tftypes.NewValue(tftypes.String, "esx-a-port1"),
+			"profile":                  tftypes.NewValue(tftypes.String, "standard"),
+			"allow_duplicate_nickname": tftypes.NewValue(tftypes.Bool, false),
+		})
+		updateResp := resource.UpdateResponse{State: prior}
+		r.Update(context.Background(), resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: prior}, &updateResp)
+		if !updateResp.Diagnostics.HasError() {
+			t.Fatalf("expected the update to be rejected")
+		}
+		updateErr := updateResp.Diagnostics.Errors()[0]
+		if updateErr.Summary() != createErr.Summary() || updateErr.Detail() != createErr.Detail() {
+			t.Fatalf("expected update and create to report the same conflict, got %q/%q and %q/%q", updateErr.Summary(), updateErr.Detail(), createErr.Summary(), createErr.Detail())
+		}
+		if sets := setCommands(); len(sets) != 0 {
+			t.Fatalf("expected no set initiator on conflict, got %v", sets)
+		}
+	})
+
+	t.Run("allows a duplicate nickname when opted in", func(t *testing.T) {
+		resp := create("20000000000000c3", "esx-a-port1", true)
+		if len(setCommands()) != 1 {
+			t.Fatalf("expected set initiator with allow_duplicate_nickname, got %v (%v)", *paths, resp.Diagnostics)
+		}
+	})
+}
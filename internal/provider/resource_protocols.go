@@ -0,0 +1,397 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const protocolsResourceID = "protocols"
+
+var _ resource.Resource = (*protocolsResource)(nil)
+var _ resource.ResourceWithImportState = (*protocolsResource)(nil)
+
+func NewProtocolsResource() resource.Resource {
+	return &protocolsResource{}
+}
+
+type protocolsResource struct {
+	client *msa.Client
+}
+
+type protocolsResourceModel struct {
+	ID         types.String     `tfsdk:"id"`
+	Activity   types.Bool       `tfsdk:"activity"`
+	Debug      types.Bool       `tfsdk:"debug"`
+	FTP        types.Bool       `tfsdk:"ftp"`
+	HTTP       types.Bool       `tfsdk:"http"`
+	HTTPS      types.Bool       `tfsdk:"https"`
+	SES        types.Bool       `tfsdk:"ses"`
+	SFTP       types.Bool       `tfsdk:"sftp"`
+	SLP        types.Bool       `tfsdk:"slp"`
+	SMIS       types.Bool       `tfsdk:"smis"`
+	SNMP       types.Bool       `tfsdk:"snmp"`
+	SSH        types.Bool       `tfsdk:"ssh"`
+	Telnet     types.Bool       `tfsdk:"telnet"`
+	USMIS      types.Bool       `tfsdk:"usmis"`
+	Properties types.Map        `tfsdk:"properties"`
+	Connection *connectionModel `tfsdk:"connection"`
+}
+
+// protocolFields returns the model's protocol attributes keyed by the
+// matching `set protocols` parameter.
+func (m *protocolsResourceModel) protocolFields() map[string]*types.Bool {
+	return map[string]*types.Bool{
+		"activity": &m.Activity,
+		"debug":    &m.Debug,
+		"ftp":      &m.FTP,
+		"http":     &m.HTTP,
+		"https":    &m.HTTPS,
+		"ses":      &m.SES,
+		"sftp":     &m.SFTP,
+		"slp":      &m.SLP,
+		"smis":     &m.SMIS,
+		"snmp":     &m.SNMP,
+		"ssh":      &m.SSH,
+		"telnet":   &m.Telnet,
+		"usmis":    &m.USMIS,
+	}
+}
+
+func (r *protocolsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_msa_protocols"
+}
+
+func (r *protocolsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	protocolAttribute := func(description string) schema.BoolAttribute {
+		return schema.BoolAttribute{
+			Description: description + " Left unmanaged when unset.",
+			Optional:    true,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages the array's management protocols via `set protocols`. Only one instance should exist per array; destroying it leaves the array settings unchanged. Settings that would leave both http and https disabled are rejected, since the provider manages the array through its web API. Import with the ID `protocols`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Fixed identifier for the singleton resource.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"activity": protocolAttribute("Activity progress reporting interface."),
+			"debug":    protocolAttribute("Telnet debug interface."),
+			"ftp":      protocolAttribute("FTP interface."),
+			"http":     protocolAttribute("Unsecure HTTP web interface."),
+			"https":    protocolAttribute("Secure HTTPS web interface."),
+			"ses":      protocolAttribute("In-band SES interface."),
+			"sftp":     protocolAttribute("SFTP interface."),
+			"slp":      protocolAttribute("Service Location Protocol."),
+			"smis":     protocolAttribute("Secure SMI-S interface."),
+			"snmp":     protocolAttribute("SNMP interface."),
+			"ssh":      protocolAttribute("SSH CLI access."),
+			"telnet":   protocolAttribute("Telnet CLI access."),
+			"usmis":    protocolAttribute("Unsecure SMI-S interface."),
+			"properties": schema.MapAttribute{
+				Description: "Raw properties returned by `show protocols`.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"connection": connectionBlock(),
+		},
+	}
+}
+
+func (r *protocolsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*msa.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data type", "Expected *msa.Client")
+		return
+	}
+
+	r.client = client
+}
+
+func (r *protocolsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan protocolsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(applyConnection(&r.client, plan.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := r.apply(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *protocolsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state protocolsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
+		return
+	}
+
+	protocols, err := r.readProtocols(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read protocols", err.Error())
+		return
+	}
+
+	if isImportedProtocolsState(state) {
+		adoptProtocols(&state, protocols)
+	}
+	newState, diags := protocolsStateFromModel(ctx, state, protocols)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *protocolsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan protocolsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(applyConnection(&r.client, plan.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state, diags := r.apply(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *protocolsResource) Delete(_ context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.AddWarning(
+		"Protocol settings left unchanged",
+		"Removing hpe_msa_protocols only drops it from Terraform state; the array keeps its current protocol settings.",
+	)
+}
+
+// ImportState records the fixed ID; the following Read fills every protocol
+// from the array.
+func (r *protocolsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if strings.TrimSpace(req.ID) != protocolsResourceID {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("hpe_msa_protocols is a singleton; import it with the ID %q.", protocolsResourceID))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), protocolsResourceID)...)
+}
+
+// apply re-sends every configured protocol setting and reads the result back.
+func (r *protocolsResource) apply(ctx context.Context, plan protocolsResourceModel) (protocolsResourceModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if r.client == nil {
+		diags.AddError("Provider not configured", "Missing MSA client")
+		return plan, diags
+	}
+
+	settings := protocolSettingsFromModel(plan)
+	parts, err := protocolsCommand(settings)
+	if err != nil {
+		diags.AddError("Invalid protocol settings", err.Error())
+		return plan, diags
+	}
+	if disablesWebProtocol(settings) {
+		// Disabling one web protocol is only safe while the array keeps the
+		// other enabled.
+		current, err := r.readProtocols(ctx)
+		if err != nil {
+			diags.AddError("Unable to read protocols", err.Error())
+			return plan, diags
+		}
+		if webAccessDisabled(settings, current.Enabled) {
+			diags.AddError("Invalid protocol settings", errWebAccessDisabled.Error())
+			return plan, diags
+		}
+	}
+	if parts != nil {
+		if _, err := r.client.Execute(ctx, parts...); err != nil {
+			diags.AddError("Unable to set protocols", err.Error())
+			return plan, diags
+		}
+	}
+
+	protocols, err := r.readProtocols(ctx)
+	if err != nil {
+		diags.AddError("Unable to read protocols after update", err.Error())
+		return plan, diags
+	}
+
+	return protocolsStateFromModel(ctx, plan, protocols)
+}
+
+func (r *protocolsResource) readProtocols(ctx context.Context) (msa.Protocols, error) {
+	response, err := r.client.Execute(ctx, "show", "protocols")
+	if err != nil {
+		return msa.Protocols{}, err
+	}
+	protocols, ok := msa.ProtocolsFromResponse(response)
+	if !ok {
+		return msa.Protocols{}, fmt.Errorf("show protocols returned no protocol settings")
+	}
+	return protocols, nil
+}
+
+func protocolSettingsFromModel(model protocolsResourceModel) map[string]bool {
+	settings := make(map[string]bool)
+	for name, value := range model.protocolFields() {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		settings[name] = value.ValueBool()
+	}
+	return settings
+}
+
+// webAccessDisabled reports whether applying settings leaves both http and
+// https disabled. Protocols that settings leave unset take their value from
+// current; one missing there too is assumed enabled.
+func webAccessDisabled(settings, current map[string]bool) bool {
+	for _, name := range []string{"http", "https"} {
+		enabled, ok := settings[name]
+		if !ok {
+			enabled, ok = current[name]
+		}
+		if !ok || enabled {
+			return false
+		}
+	}
+	return true
+}
+
+// disablesWebProtocol reports whether settings explicitly disable http or
+// https.
+func disablesWebProtocol(settings map[string]bool) bool {
+	for _, name := range []string{"http", "https"} {
+		if enabled, ok := settings[name]; ok && !enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// errWebAccessDisabled rejects settings that would leave no web interface:
+// the provider manages the array through its web API, so it would lock
+// itself (and the web UI) out.
+var errWebAccessDisabled = errors.New("disabling both http and https would cut off the web API this provider manages the array through; keep at least one enabled")
+
+// protocolsCommand builds a single `set protocols` command in a stable order.
+// It returns nil when nothing is configured.
+func protocolsCommand(settings map[string]bool) ([]string, error) {
+	if len(settings) == 0 {
+		return nil, nil
+	}
+	if webAccessDisabled(settings, nil) {
+		return nil, errWebAccessDisabled
+	}
+
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		if !msa.IsKnownProtocol(name) {
+			return nil, fmt.Errorf("unknown protocol %q (expected one of: %s)", name, strings.Join(msa.ProtocolNames(), ", "))
+		}
+		names = append(names, strings.ToLower(strings.TrimSpace(name)))
+	}
+	sort.Strings(names)
+
+	parts := []string{"set", "protocols"}
+	for _, name := range names {
+		value := "disabled"
+		if settings[name] {
+			value = "enabled"
+		}
+		parts = append(parts, name, value)
+	}
+	return parts, nil
+}
+
+// isImportedProtocolsState reports whether state comes straight from
+// ImportState: no protocol is tracked and the array was never read. A
+// resource created without any protocols has properties and stays empty.
+func isImportedProtocolsState(state protocolsResourceModel) bool {
+	if !state.Properties.IsNull() {
+		return false
+	}
+	for _, field := range state.protocolFields() {
+		if !field.IsNull() {
+			return false
+		}
+	}
+	return true
+}
+
+// adoptProtocols sets every protocol the array reports, so an imported
+// resource tracks the array's current settings.
+func adoptProtocols(state *protocolsResourceModel, protocols msa.Protocols) {
+	for name, field := range state.protocolFields() {
+		if enabled, ok := protocols.Enabled[name]; ok {
+			*field = types.BoolValue(enabled)
+		}
+	}
+}
+
+// protocolsStateFromModel refreshes managed protocols from the array. Unset
+// protocols stay null so the resource only tracks what the user configured.
+func protocolsStateFromModel(ctx context.Context, model protocolsResourceModel, protocols msa.Protocols) (protocolsResourceModel, diag.Diagnostics) {
+	state := model
+	var diags diag.Diagnostics
+
+	state.ID = types.StringValue(protocolsResourceID)
+	for name, field := range state.protocolFields() {
+		if field.IsNull() {
+			continue
+		}
+		if enabled, ok := protocols.Enabled[name]; ok {
+			*field = types.BoolValue(enabled)
+		}
+	}
+
+	propsValue, diag := types.MapValueFrom(ctx, types.StringType, protocols.Properties)
+	diags.Append(diag...)
+	if diags.HasError() {
+		return state, diags
+	}
+	state.Properties = propsValue
+
+	return state, diags
+}
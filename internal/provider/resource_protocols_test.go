@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProtocolsCommand(t *testing.T) {
+	parts, err := protocolsCommand(map[string]bool{
+		"telnet": false,
+		"ssh":    true,
+		"http":   false,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := strings.Join(parts, " ")
+	want := "set protocols http disabled ssh enabled telnet disabled"
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	parts, err = protocolsCommand(nil)
+	if err != nil || parts != nil {
+		t.Fatalf("expected no command for empty settings, got %v (%v)", parts, err)
+	}
+
+	if _, err := protocolsCommand(map[string]bool{"gopher": true}); err == nil {
+		t.Fatalf("expected unknown protocol to be rejected")
+	}
+	if _, err := protocolsCommand(map[string]bool{"http": false, "https": false}); !errors.Is(err, errWebAccessDisabled) {
+		t.Fatalf("expected disabling both web protocols to be rejected, got %v", err)
+	}
+}
+
+func TestWebAccessDisabled(t *testing.T) {
+	testCases := []struct {
+		name     string
+		settings map[string]bool
+		current  map[string]bool
+		want     bool
+	}{
+		{name: "both disabled", settings: map[string]bool{"http": false, "https": false}, want: true},
+		{name: "https kept", settings: map[string]bool{"http": false, "https": true}, want: false},
+		{name: "other disabled on array", settings: map[string]bool{"https": false}, current: map[string]bool{"http": false, "https": true}, want: true},
+		{name: "other enabled on array", settings: map[string]bool{"https": false}, current: map[string]bool{"http": true, "https": true}, want: false},
+		{name: "other not reported", settings: map[string]bool{"https": false}, current: map[string]bool{}, want: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := webAccessDisabled(tc.settings, tc.current); got != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestProtocolsImportState(t *testing.T) {
+	r := &protocolsResource{}
+
+	resp := resource.ImportStateResponse{State: resourceState(t, r, nil)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: "protocols"}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("import failed: %v", resp.Diagnostics)
+	}
+	var got protocolsResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &got)...)
+	if got.ID.ValueString() != protocolsResourceID {
+		t.Fatalf("expected id %q, got %+v", protocolsResourceID, got)
+	}
+
+	resp = resource.ImportStateResponse{State: resourceState(t, r, nil)}
+	r.ImportState(context.Background(), resource.ImportStateRequest{ID: "other"}, &resp)
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected an unexpected import ID to be rejected")
+	}
+}
+
+func TestProtocolsReadAfterImportFillsEveryProtocol(t *testing.T) {
+	fixture, err := os.ReadFile(filepath.Join("..", "msa", "testdata", "show_protocols.xml"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	server, _ := newMSATestServer(t, func(path string) string {
+		if path != "/api/show/protocols" {
+			return `<RESPONSE VERSION="L100"></RESPONSE>`
+		}
+		return string(fixture)
+	})
+	client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	ctx := context.Background()
+	r := &protocolsResource{client: client}
+
+	importResp := resource.ImportStateResponse{State: resourceState(t, r, nil)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: protocolsResourceID}, &importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("import: %v", importResp.Diagnostics)
+	}
+	readResp := resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("read after import: %v", readResp.Diagnostics)
+	}
+
+	var got protocolsResourceModel
+	readResp.Diagnostics.Append(readResp.State.Get(ctx, &got)...)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("state: %v", readResp.Diagnostics)
+	}
+	want := map[string]bool{
+		"activity": false, "debug": false, "ftp": false, "http": false, "https": true,
+		"ses": true, "sftp": true, "slp": true, "smis": false, "snmp": true,
+		"ssh": true, "telnet": false, "usmis": false,
+	}
+	for name, field := range got.protocolFields() {
+		if field.IsNull() || field.ValueBool() != want[name] {
+			t.Fatalf("expected %s=%v after import, got %v", name, want[name], *field)
+		}
+	}
+
+	// A resource created without protocols has been read before and must
+	// keep tracking none of them.
+	created := protocolsResourceModel{
+		ID:         types.StringValue(protocolsResourceID),
+		Properties: types.MapValueMust(types.StringType, map[string]attr.Value{}),
+	}
+	for _, field := range created.protocolFields() {
+		*field = types.BoolNull()
+	}
+	if isImportedProtocolsState(created) {
+		t.Fatalf("expected a created resource without protocols not to be treated as imported")
+	}
+}
+
+func TestProtocolsStateFromModel(t *testing.T) {
+	model := protocolsResourceModel{
+		SSH:    types.BoolValue(false),
+		Telnet: types.BoolValue(true),
+		HTTP:   types.BoolNull(),
+	}
+	protocols := msa.Protocols{
+		Enabled: map[string]bool{
+			"ssh":    true,
+			"telnet": false,
+			"http":   true,
+		},
+		Properties: map[string]string{"cli-ssh": "Enabled"},
+	}
+
+	state, diags := protocolsStateFromModel(context.Background(), model, protocols)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if state.ID.ValueString() != protocolsResourceID {
+		t.Fatalf("unexpected id %q", state.ID.ValueString())
+	}
+	if !state.SSH.ValueBool() || state.Telnet.ValueBool() {
+		t.Fatalf("expected managed protocols to reflect the array, got ssh=%v telnet=%v", state.SSH, state.Telnet)
+	}
+	if !state.HTTP.IsNull() {
+		t.Fatalf("expected unmanaged protocol to stay null, got %v", state.HTTP)
+	}
+}
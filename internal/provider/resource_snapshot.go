@@ -30,16 +30,18 @@ type snapshotResource struct {
 }
 
 type snapshotResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	VolumeName   types.String `tfsdk:"volume_name"`
-	SerialNumber types.String `tfsdk:"serial_number"`
-	DurableID    types.String `tfsdk:"durable_id"`
-	Pool         types.String `tfsdk:"pool"`
-	VDisk        types.String `tfsdk:"vdisk"`
-	Size         types.String `tfsdk:"size"`
-	Properties   types.Map    `tfsdk:"properties"`
-	AllowDestroy types.Bool   `tfsdk:"allow_destroy"`
+	ID            types.String     `tfsdk:"id"`
+	Name          types.String     `tfsdk:"name"`
+	VolumeName    types.String     `tfsdk:"volume_name"`
+	SerialNumber  types.String     `tfsdk:"serial_number"`
+	DurableID     types.String     `tfsdk:"durable_id"`
+	Pool          types.String     `tfsdk:"pool"`
+	VDisk         types.String     `tfsdk:"vdisk"`
+	Size          types.String     `tfsdk:"size"`
+	Properties    types.Map        `tfsdk:"properties"`
+	AllowDestroy  types.Bool       `tfsdk:"allow_destroy"`
+	AdoptExisting types.Bool       `tfsdk:"adopt_existing"`
+	Connection    *connectionModel `tfsdk:"connection"`
 }
 
 func (r *snapshotResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -98,6 +100,15 @@ func (r *snapshotResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Adopt an existing snapshot with the same name when it belongs to volume_name, instead of failing the create.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"connection": connectionBlock(),
 		},
 	}
 }
@@ -122,6 +133,10 @@ func (r *snapshotResource) Create(ctx context.Context, req resource.CreateReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, plan.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
@@ -135,22 +150,39 @@ func (r *snapshotResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	_, err := r.findSnapshot(ctx, name, "")
-	if err == nil {
-		resp.Diagnostics.AddError("Snapshot already exists", "Import the snapshot or choose a different name.")
-		return
-	}
-	if err != nil && !errors.Is(err, errSnapshotNotFound) {
-		resp.Diagnostics.AddError("Unable to check existing snapshots", err.Error())
+	_, outcome, diags := reconcileBeforeCreate(ctx, existingObjectCheck[msa.Snapshot]{
+		Kind:  "Snapshot",
+		Name:  name,
+		Adopt: plan.AdoptExisting.ValueBool(),
+		Find: func(ctx context.Context) (*msa.Snapshot, error) {
+			return r.findSnapshot(ctx, name, "")
+		},
+		NotFound: errSnapshotNotFound,
+		Differences: func(snapshot *msa.Snapshot) []string {
+			if strings.EqualFold(snapshot.BaseVolumeName, volumeName) {
+				return nil
+			}
+			return []string{fmt.Sprintf("base volume is %q, want %q", snapshot.BaseVolumeName, volumeName)}
+		},
+	})
+	resp.Diagnostics.Append(diags...)
+	if outcome == reconcileConflict {
 		return
 	}
 
 	shouldValidate := false
-	_, err = r.client.Execute(ctx, "create", "snapshots", "volumes", volumeName, name)
+	var err error
+	if outcome == reconcileCreate {
+		_, err = r.client.Execute(ctx, "create", "snapshots", "volumes", volumeName, name)
+	}
 	if err != nil {
 		var apiErr msa.APIError
 		if errors.As(err, &apiErr) {
 			msg := strings.ToLower(apiErr.Status.Response)
+			if isObjectLimitError(err) {
+				resp.Diagnostics.AddError(objectLimitDiagnostic(ctx, r.client, "snapshot", err))
+				return
+			}
 			if strings.Contains(msg, "snapshot(s) were created") {
 				shouldValidate = true
 			} else if strings.Contains(msg, "name") && strings.Contains(msg, "already") {
@@ -194,6 +226,10 @@ func (r *snapshotResource) Read(ctx context.Context, req resource.ReadRequest, r
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
@@ -221,7 +257,19 @@ func (r *snapshotResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *snapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Update not supported", "Snapshot updates require replacement")
+	var plan snapshotResourceModel
+	var state snapshotResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// name and volume_name require replacement, so only provider-side flags
+	// (allow_destroy, adopt_existing) can change in place.
+	state.AllowDestroy = plan.AllowDestroy
+	state.AdoptExisting = plan.AdoptExisting
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *snapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -230,17 +278,17 @@ func (r *snapshotResource) Delete(ctx context.Context, req resource.DeleteReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	if r.client == nil {
-		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
+	resp.Diagnostics.Append(checkAllowDestroy(state.AllowDestroy, "hpe_msa_snapshot", state.Name.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if state.AllowDestroy.IsUnknown() || !state.AllowDestroy.ValueBool() {
-		resp.Diagnostics.AddError(
-			"Deletion blocked",
-			"Set allow_destroy = true to permit snapshot deletion.",
-		)
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
 	}
 
@@ -268,7 +316,7 @@ func (r *snapshotResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	_, err = r.client.Execute(ctx, "delete", "snapshot", target)
+	err = deleteTolerant(ctx, r.client, "delete", "snapshot", target)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to delete snapshot", err.Error())
 		return
@@ -277,6 +325,7 @@ func (r *snapshotResource) Delete(ctx context.Context, req resource.DeleteReques
 
 func (r *snapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	setImportDefaults(ctx, resp, "allow_destroy", "adopt_existing")
 }
 
 var errSnapshotNotFound = errors.New("snapshot not found")
@@ -306,6 +355,9 @@ func (r *snapshotResource) findSnapshot(ctx context.Context, name, id string) (*
 func (r *snapshotResource) waitForSnapshot(ctx context.Context, name, id string) (*msa.Snapshot, error) {
 	waits := []time.Duration{1 * time.Second, 2 * time.Second, 3 * time.Second}
 	for i, wait := range waits {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		snapshot, err := r.findSnapshot(ctx, name, id)
 		if err == nil {
 			return snapshot, nil
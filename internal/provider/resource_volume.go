@@ -11,12 +11,14 @@ import (
 	"time"
 
 	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -32,16 +34,24 @@ type volumeResource struct {
 }
 
 type volumeResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	Size         types.String `tfsdk:"size"`
-	Pool         types.String `tfsdk:"pool"`
-	VDisk        types.String `tfsdk:"vdisk"`
-	DurableID    types.String `tfsdk:"durable_id"`
-	SerialNumber types.String `tfsdk:"serial_number"`
-	WWID         types.String `tfsdk:"wwid"`
-	SCSIWWN      types.String `tfsdk:"scsi_wwn"`
-	AllowDestroy types.Bool   `tfsdk:"allow_destroy"`
+	ID             types.String     `tfsdk:"id"`
+	Name           types.String     `tfsdk:"name"`
+	Size           types.String     `tfsdk:"size"`
+	Pool           types.String     `tfsdk:"pool"`
+	VDisk          types.String     `tfsdk:"vdisk"`
+	FallbackPools  types.List       `tfsdk:"fallback_pools"`
+	ActualPool     types.String     `tfsdk:"actual_pool"`
+	DurableID      types.String     `tfsdk:"durable_id"`
+	SerialNumber   types.String     `tfsdk:"serial_number"`
+	WWID           types.String     `tfsdk:"wwid"`
+	SCSIWWN        types.String     `tfsdk:"scsi_wwn"`
+	AllocatedSize  types.Int64      `tfsdk:"allocated_size"`
+	AllocatedPages types.Int64      `tfsdk:"allocated_pages"`
+	ReadAheadSize  types.String     `tfsdk:"read_ahead_size"`
+	VerifyUnmapped types.Bool       `tfsdk:"verify_unmapped"`
+	AllowDestroy   types.Bool       `tfsdk:"allow_destroy"`
+	AdoptExisting  types.Bool       `tfsdk:"adopt_existing"`
+	Connection     *connectionModel `tfsdk:"connection"`
 }
 
 func (r *volumeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -63,7 +73,7 @@ func (r *volumeResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				},
 			},
 			"size": schema.StringAttribute{
-				Description: "Volume size (e.g., 100GB).",
+				Description: "Volume size (e.g., 100GB, 1TiB, or 2097152blocks for 512-byte blocks). A bare B/b suffix means bytes, not blocks.",
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -85,6 +95,18 @@ func (r *volumeResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"fallback_pools": schema.ListAttribute{
+				Description: "Pools to try in order when the array reports insufficient space in pool/vdisk. Unset by default.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"actual_pool": schema.StringAttribute{
+				Description: "Pool the volume was created in; differs from pool/vdisk when a fallback pool was used.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"durable_id": schema.StringAttribute{
 				Description: "Durable ID reported by the array.",
 				Computed:    true,
@@ -101,12 +123,46 @@ func (r *volumeResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Description: "Host-visible SCSI WWN/NAA identifier reported by the array.",
 				Computed:    true,
 			},
+			"allocated_size": schema.Int64Attribute{
+				Description: "Bytes actually allocated to the volume; below the advertised size for thin volumes.",
+				Computed:    true,
+			},
+			"allocated_pages": schema.Int64Attribute{
+				Description: "Number of 4 MiB pool pages allocated to the volume.",
+				Computed:    true,
+			},
+			"read_ahead_size": schema.StringAttribute{
+				Description: "Cache read-ahead: adaptive, disabled, stripe, or a fixed size from 64KB to 32MB (powers of two). Changed in place with `set volume read-ahead-size`; defaults to the array's setting when omitted.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					readAheadSizeValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"verify_unmapped": schema.BoolAttribute{
+				Description: "After create, check `show maps volume` and warn if the new volume is already presented to a host (e.g. through a default mapping).",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 			"allow_destroy": schema.BoolAttribute{
 				Description: "Require explicit opt-in to delete volumes.",
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Adopt an existing volume with the same name when its pool and size match the configuration, instead of failing the create.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"connection": connectionBlock(),
 		},
 	}
 }
@@ -131,6 +187,10 @@ func (r *volumeResource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, plan.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	var configPool types.String
 	var configVDisk types.String
@@ -175,28 +235,69 @@ func (r *volumeResource) Create(ctx context.Context, req resource.CreateRequest,
 			resp.Diagnostics.AddError("Invalid configuration", err.Error())
 			return
 		}
+	} else {
+		target, err = resolvePlacementName(ctx, r.client, target)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to resolve pool or vdisk", err.Error())
+			return
+		}
+	}
+
+	sizeParameter, err := volumeSizeParameter(size)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid size", err.Error())
+		return
 	}
 
-	_, err = r.findVolume(ctx, name, "")
-	if err == nil {
-		resp.Diagnostics.AddError("Volume already exists", "Import the volume or choose a different name.")
+	pools, err := volumeCreatePools(ctx, r.client, target, fallbackPoolsFromModel(plan.FallbackPools))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to resolve fallback pool", err.Error())
 		return
 	}
-	if err != nil && !errors.Is(err, errVolumeNotFound) {
-		resp.Diagnostics.AddError("Unable to check existing volumes", err.Error())
+
+	existing, outcome, diags := reconcileBeforeCreate(ctx, existingObjectCheck[msa.Volume]{
+		Kind:  "Volume",
+		Name:  name,
+		Adopt: plan.AdoptExisting.ValueBool(),
+		Find: func(ctx context.Context) (*msa.Volume, error) {
+			return r.findVolume(ctx, name, "")
+		},
+		NotFound: errVolumeNotFound,
+		Differences: func(volume *msa.Volume) []string {
+			return volumeSpecDifferences(volume, pools, size)
+		},
+	})
+	resp.Diagnostics.Append(diags...)
+	if outcome == reconcileConflict {
 		return
 	}
 
 	shouldValidate := false
-	// MSA XML API expects pool + access parameters for volume creation.
-	_, err = r.client.Execute(ctx, "create", "volume", name, "pool", target, "size", size, "access", "no-access")
+	if outcome == reconcileAdopt {
+		target = firstNonEmpty(existing.PoolName, existing.VDiskName, target)
+	} else {
+		target, err = createVolumeInPools(ctx, r.client, name, sizeParameter, pools)
+	}
 	if err != nil {
 		var apiErr msa.APIError
 		if errors.As(err, &apiErr) {
 			msg := strings.ToLower(apiErr.Status.Response)
+			if isObjectLimitError(err) {
+				resp.Diagnostics.AddError(objectLimitDiagnostic(ctx, r.client, "volume", err))
+				return
+			}
 			if strings.Contains(msg, "volume was created") || strings.Contains(msg, "name is already in use") || strings.Contains(msg, "name already in use") {
 				// Some firmware revisions report a non-zero response even though the volume exists.
 				shouldValidate = true
+			} else if !apiErr.PermissionDenied && volumeExists(ctx, r.client, name) {
+				// Localized arrays report the same outcome in another language;
+				// the volume did not exist before the command, so check the array
+				// instead of the message.
+				tflog.Warn(ctx, "volume create reported an error but the volume exists; validating it", map[string]any{
+					"volume": name,
+					"error":  err.Error(),
+				})
+				shouldValidate = true
 			} else {
 				resp.Diagnostics.AddError("Unable to create volume", err.Error())
 				return
@@ -236,8 +337,48 @@ func (r *volumeResource) Create(ctx context.Context, req resource.CreateRequest,
 		}
 	}
 
+	if readAhead, ok := plannedReadAheadSize(plan.ReadAheadSize); ok {
+		if err := r.setReadAheadSize(ctx, readAhead, volume); err != nil {
+			resp.Diagnostics.AddError("Unable to set read-ahead size", err.Error())
+			state := volumeStateFromModel(plan, volume)
+			state.ReadAheadSize = readAheadStateValue(types.StringNull(), volume.ReadAheadSize)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+		volume, err = r.findVolume(ctx, volume.Name, volume.SerialNumber)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read volume after create", err.Error())
+			return
+		}
+	}
+
 	state := volumeStateFromModel(plan, volume)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	if plan.VerifyUnmapped.ValueBool() {
+		if summary, detail, ok := unexpectedVolumeMappings(ctx, r.client, volume); ok {
+			resp.Diagnostics.AddWarning(summary, detail)
+		}
+	}
+}
+
+// unexpectedVolumeMappings checks that a volume created with `access
+// no-access` is not presented to any host, which would point to a default
+// mapping configured on the array. It returns a warning when a mapping is
+// found or the check could not run.
+func unexpectedVolumeMappings(ctx context.Context, client volumeDeleteProbeClient, volume *msa.Volume) (string, string, bool) {
+	identities := volumeIdentityHints(volume.Name, volume.SerialNumber)
+	count, command, err := probeVolumeMappings(ctx, client, identities)
+	if err != nil {
+		return "Unable to verify volume is unmapped",
+			fmt.Sprintf("Checking mappings of volume %q after create failed: %v", volume.Name, err), true
+	}
+	if count == 0 {
+		return "", "", false
+	}
+	return "Volume mapped right after create",
+		fmt.Sprintf("Volume %q was created with no access but is already presented by %d %s (detected via `%s`). A default mapping on the array may expose it to hosts before it is mapped explicitly; review `show maps volume %s`.",
+			volume.Name, count, pluralize(count, "mapping", "mappings"), command, volume.Name), true
 }
 
 func (r *volumeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -246,6 +387,10 @@ func (r *volumeResource) Read(ctx context.Context, req resource.ReadRequest, res
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
@@ -267,27 +412,68 @@ func (r *volumeResource) Read(ctx context.Context, req resource.ReadRequest, res
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
 }
 
+// Update applies the in-place settings (read_ahead_size, allow_destroy);
+// every other attribute forces replacement.
 func (r *volumeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Update not supported", "Volume updates require replacement")
-}
-
-func (r *volumeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var plan volumeResourceModel
 	var state volumeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, plan.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
 	}
 
-	if state.AllowDestroy.IsUnknown() || !state.AllowDestroy.ValueBool() {
-		resp.Diagnostics.AddError(
-			"Deletion blocked",
-			"Set allow_destroy = true to permit volume deletion.",
-		)
+	volume, err := r.findVolume(ctx, state.Name.ValueString(), strings.TrimSpace(state.ID.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read volume", err.Error())
+		return
+	}
+
+	if readAhead, ok := plannedReadAheadSize(plan.ReadAheadSize); ok && !readAheadSizeMatches(readAhead, volume.ReadAheadSize) {
+		if err := r.setReadAheadSize(ctx, readAhead, volume); err != nil {
+			resp.Diagnostics.AddError("Unable to set read-ahead size", err.Error())
+			return
+		}
+		volume, err = r.findVolume(ctx, volume.Name, volume.SerialNumber)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read volume after update", err.Error())
+			return
+		}
+	}
+
+	if plan.ActualPool.IsUnknown() {
+		plan.ActualPool = state.ActualPool
+	}
+	newState := volumeStateFromModel(plan, volume)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *volumeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state volumeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(checkAllowDestroy(state.AllowDestroy, "hpe_msa_volume", state.Name.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
 	}
 
@@ -301,45 +487,35 @@ func (r *volumeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	if guardrail, ok := preDeleteVolumeUsageGuardrail(ctx, r.client, "volume", target, state.Name.ValueString(), id); ok {
-		resp.Diagnostics.AddError(guardrail.summary, guardrail.detail)
-		return
-	}
-
 	lockOwner := fmt.Sprintf("volume:%s", target)
-	lock, err := acquireDestroyGlobalLock(ctx, lockOwner)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to acquire destroy global lock", err.Error())
-		return
-	}
-	defer func() {
-		if releaseErr := lock.Release(ctx); releaseErr != nil {
-			tflog.Warn(ctx, "release MSA destroy global lock failed", map[string]any{
-				"lock_owner": lockOwner,
-				"error":      releaseErr.Error(),
-			})
+	resp.Diagnostics.Append(withDestroyLock(ctx, lockOwner, func() diag.Diagnostics {
+		var diags diag.Diagnostics
+		if guardrail, ok := preDeleteVolumeUsageGuardrail(ctx, r.client, "volume", target, state.Name.ValueString(), id); ok {
+			diags.AddError(guardrail.summary, guardrail.detail)
+			return diags
 		}
-	}()
 
-	_, err = r.client.Execute(ctx, "delete", "volumes", target)
-	if err != nil {
-		if guardrail, ok := classifyVolumeDeleteError("volume", target, err); ok {
-			resp.Diagnostics.AddError(guardrail.summary, guardrail.detail)
-			return
+		if err := deleteTolerant(ctx, r.client, "delete", "volumes", target); err != nil {
+			if guardrail, ok := classifyVolumeDeleteError("volume", target, err); ok {
+				diags.AddError(guardrail.summary, guardrail.detail)
+				return diags
+			}
+			diags.AddError("Unable to delete volume", err.Error())
 		}
-		resp.Diagnostics.AddError("Unable to delete volume", err.Error())
-		return
-	}
+		return diags
+	})...)
 }
 
 func (r *volumeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	setImportDefaults(ctx, resp, "verify_unmapped", "allow_destroy", "adopt_existing")
 }
 
 var errVolumeNotFound = errors.New("volume not found")
 var errVolumeTargetMissing = errors.New("volume target missing")
 var errVolumeTargetConflict = errors.New("volume target conflict")
 var errVolumeTargetUnknown = errors.New("volume target unknown")
+var errVolumeTargetNotFound = errors.New("pool or vdisk not found")
 
 type volumeDeleteGuardrail struct {
 	summary   string
@@ -493,9 +669,27 @@ func (r *volumeResource) findVolume(ctx context.Context, name, id string) (*msa.
 	return nil, errVolumeNotFound
 }
 
+// volumeExists reports whether `show volumes` lists the volume. Lookup
+// failures count as absent.
+func volumeExists(ctx context.Context, client commandExecutor, name string) bool {
+	response, err := client.Execute(ctx, "show", "volumes", name)
+	if err != nil {
+		return false
+	}
+	for _, volume := range msa.VolumesFromResponse(response) {
+		if strings.EqualFold(volume.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *volumeResource) waitForVolume(ctx context.Context, name, id string) (*msa.Volume, error) {
 	waits := []time.Duration{1 * time.Second, 2 * time.Second, 3 * time.Second}
 	for i, wait := range waits {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		volume, err := r.findVolume(ctx, name, id)
 		if err == nil {
 			return volume, nil
@@ -580,16 +774,93 @@ func poolNamesFromResponse(response msa.Response) []string {
 	return names
 }
 
+// resolvePlacementName maps a configured pool/vdisk name to the array's
+// casing using `show pools`, then `show disk-groups`. An exact match wins;
+// otherwise a single case-insensitive match is used.
+func resolvePlacementName(ctx context.Context, client commandExecutor, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	available := make([]string, 0)
+	var queryErrs []error
+	for _, command := range [][]string{{"show", "pools"}, {"show", "disk-groups"}} {
+		response, err := client.Execute(ctx, command...)
+		if err != nil {
+			queryErrs = append(queryErrs, fmt.Errorf("%s: %w", strings.Join(command, " "), err))
+			continue
+		}
+
+		var names []string
+		if command[1] == "pools" {
+			names = poolNamesFromResponse(response)
+		} else {
+			for _, group := range msa.DiskGroupsFromResponse(response) {
+				names = append(names, group.Name)
+			}
+		}
+		canonical, err := matchPlacementName(names, name)
+		if err != nil {
+			return "", err
+		}
+		if canonical != "" {
+			return canonical, nil
+		}
+		available = append(available, names...)
+	}
+
+	if len(queryErrs) == 2 {
+		return "", fmt.Errorf("unable to query pools or disk groups: %w", errors.Join(queryErrs...))
+	}
+	if len(available) == 0 {
+		return "", fmt.Errorf("%w: %q", errVolumeTargetNotFound, name)
+	}
+	return "", fmt.Errorf("%w: %q (available: %s)", errVolumeTargetNotFound, name, strings.Join(available, ", "))
+}
+
+func matchPlacementName(names []string, name string) (string, error) {
+	folded := make([]string, 0, 1)
+	for _, candidate := range names {
+		if candidate == name {
+			return candidate, nil
+		}
+		if strings.EqualFold(candidate, name) {
+			folded = append(folded, candidate)
+		}
+	}
+	if len(folded) > 1 {
+		return "", fmt.Errorf("pool or vdisk %q matches more than one name when ignoring case (%s); use the exact name", name, strings.Join(folded, ", "))
+	}
+	if len(folded) == 1 {
+		return folded[0], nil
+	}
+	return "", nil
+}
+
+// volumeCreateCommand assembles `create volume`. The MSA XML API expects pool
+// + access parameters for volume creation. `create volume` has no
+// initialize/format option (virtual volumes are thin and read back as zeroes),
+// so no such flag is offered; zero or format the LUN from the host instead.
+func volumeCreateCommand(name, target, size string) []string {
+	return []string{"create", "volume", name, "pool", target, "size", size, "access", "no-access"}
+}
+
 func volumeStateFromModel(model volumeResourceModel, volume *msa.Volume) volumeResourceModel {
 	state := model
 	state.Name = types.StringValue(volume.Name)
 
-	if volume.PoolName != "" {
+	// Keep the configured casing when it only differs from the array's, so a
+	// case-insensitive match does not force replacement. A volume placed in
+	// a fallback pool also keeps the configured pool.
+	fallbacks := fallbackPoolsFromModel(model.FallbackPools)
+	if volume.PoolName != "" && !keepConfiguredPlacement(model.Pool, volume.PoolName, model.ActualPool, fallbacks) {
 		state.Pool = types.StringValue(volume.PoolName)
 	}
-	if volume.VDiskName != "" {
+	if volume.VDiskName != "" && !keepConfiguredPlacement(model.VDisk, volume.VDiskName, model.ActualPool, fallbacks) {
 		state.VDisk = types.StringValue(volume.VDiskName)
 	}
+	if actual := firstNonEmpty(volume.PoolName, volume.VDiskName); actual != "" {
+		state.ActualPool = types.StringValue(actual)
+	} else if state.ActualPool.IsUnknown() {
+		state.ActualPool = types.StringNull()
+	}
 	if volume.DurableID != "" {
 		state.DurableID = types.StringValue(volume.DurableID)
 	}
@@ -603,10 +874,83 @@ func volumeStateFromModel(model volumeResourceModel, volume *msa.Volume) volumeR
 	} else {
 		state.SCSIWWN = types.StringNull()
 	}
+	state.AllocatedSize = int64ValueOrNull(volume.AllocatedBytes())
+	state.AllocatedPages = int64ValueOrNull(volume.AllocatedPages())
+	state.ReadAheadSize = readAheadStateValue(model.ReadAheadSize, volume.ReadAheadSize)
 
 	return state
 }
 
+// keepConfiguredPlacement reports whether state keeps the configured pool
+// for a volume the array reports in actual. Once actual_pool is recorded the
+// decision follows it rather than the current fallback_pools, so editing or
+// removing the fallback list never rewrites pool and forces a replacement.
+// Before the first read (during Create) the fallback list decides.
+func keepConfiguredPlacement(configured types.String, actual string, recorded types.String, fallbacks []string) bool {
+	if configured.IsNull() || configured.IsUnknown() || configured.ValueString() == "" {
+		return false
+	}
+	if strings.EqualFold(configured.ValueString(), actual) {
+		return true
+	}
+	if !recorded.IsNull() && !recorded.IsUnknown() && strings.TrimSpace(recorded.ValueString()) != "" {
+		return strings.EqualFold(recorded.ValueString(), actual)
+	}
+	return containsFold(fallbacks, actual)
+}
+
+func (r *volumeResource) setReadAheadSize(ctx context.Context, readAhead string, volume *msa.Volume) error {
+	target := firstNonEmpty(volume.SerialNumber, volume.Name)
+	if _, err := r.client.Execute(ctx, readAheadSizeCommand(readAhead, target)...); err != nil {
+		if containsAny(strings.ToLower(err.Error()), "unrecognized", "invalid parameter", "not supported", "unknown parameter") {
+			return fmt.Errorf("%w (the array firmware may not support read-ahead-size on `set volume`)", err)
+		}
+		return err
+	}
+	return nil
+}
+
+func readAheadSizeCommand(readAhead, volume string) []string {
+	return []string{"set", "volume", "read-ahead-size", readAhead, volume}
+}
+
+// plannedReadAheadSize returns the normalized configured read-ahead setting;
+// ok is false when the attribute is unset and the array's value is kept.
+func plannedReadAheadSize(value types.String) (string, bool) {
+	if value.IsNull() || value.IsUnknown() {
+		return "", false
+	}
+	normalized, err := normalizeReadAheadSize(value.ValueString())
+	if err != nil {
+		return "", false
+	}
+	return normalized, true
+}
+
+func readAheadSizeMatches(configured, reported string) bool {
+	normalized, err := normalizeReadAheadSize(reported)
+	return err == nil && normalized == configured
+}
+
+// readAheadStateValue records the array's read-ahead setting, keeping the
+// configured spelling (e.g. "1mb" or "1048576") when it means the same thing.
+func readAheadStateValue(prior types.String, reported string) types.String {
+	reported = strings.TrimSpace(reported)
+	if reported == "" {
+		if prior.IsUnknown() {
+			return types.StringNull()
+		}
+		return prior
+	}
+	if configured, ok := plannedReadAheadSize(prior); ok && readAheadSizeMatches(configured, reported) {
+		return prior
+	}
+	if normalized, err := normalizeReadAheadSize(reported); err == nil {
+		return types.StringValue(normalized)
+	}
+	return types.StringValue(reported)
+}
+
 func volumeMatchesTarget(volume *msa.Volume, target string) bool {
 	target = strings.TrimSpace(target)
 	if target == "" {
@@ -621,6 +965,30 @@ func volumeMatchesTarget(volume *msa.Volume, target string) bool {
 	return false
 }
 
+// volumeSpecDifferences compares an existing volume with the pools it may be
+// created in and the requested size.
+func volumeSpecDifferences(volume *msa.Volume, pools []string, size string) []string {
+	differences := make([]string, 0, 2)
+	placement := firstNonEmpty(volume.PoolName, volume.VDiskName)
+	placed := false
+	for _, pool := range pools {
+		if volumeMatchesTarget(volume, pool) {
+			placed = true
+			break
+		}
+	}
+	if !placed {
+		differences = append(differences, fmt.Sprintf("pool is %q, want %q", placement, strings.Join(pools, ", ")))
+	}
+	match, err := volumeSizeMatches(size, volume)
+	if err != nil {
+		differences = append(differences, fmt.Sprintf("size could not be compared: %v", err))
+	} else if !match {
+		differences = append(differences, fmt.Sprintf("size is %q, want %q", volume.Size, size))
+	}
+	return differences
+}
+
 func volumeSizeMatches(planSize string, volume *msa.Volume) (bool, error) {
 	planBytes, err := parseSizeToBytes(planSize)
 	if err != nil {
@@ -633,7 +1001,7 @@ func volumeSizeMatches(planSize string, volume *msa.Volume) (bool, error) {
 	if err != nil {
 		return false, fmt.Errorf("invalid size-numeric %q", volume.SizeNumeric)
 	}
-	volumeBytes := blocks * 512
+	volumeBytes := blocks * volume.BlockSize()
 	diff := int64(math.Abs(float64(planBytes - volumeBytes)))
 	tolerance := sizeTolerance(planBytes)
 	return diff <= tolerance, nil
@@ -648,6 +1016,15 @@ func sizeTolerance(planBytes int64) int64 {
 	return relative
 }
 
+// sizeBlockBytes is the size of the block unit accepted in size ("blocks",
+// "blk"). It is fixed so that create and the later size comparison agree on
+// what a configured block count means, whatever block size the volume
+// reports.
+const sizeBlockBytes = 512
+
+// parseSizeToBytes converts a size with a decimal or binary unit, or a whole
+// number of 512-byte blocks ("2097152blocks", "2097152 blk"), to bytes. A
+// bare "B" or "b" always means bytes, as it does for the array.
 func parseSizeToBytes(raw string) (int64, error) {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
@@ -693,6 +1070,12 @@ func parseSizeToBytes(raw string) (int64, error) {
 		"PIB": 1024 * 1024 * 1024 * 1024 * 1024,
 	}
 
+	if isBlockSizeUnit(unit) {
+		if strings.Contains(matches[1], ".") {
+			return 0, fmt.Errorf("invalid size %q: block counts must be whole numbers", raw)
+		}
+		return sizeToBytes(value, sizeBlockBytes, raw)
+	}
 	if multiplier, ok := decimalUnits[unit]; ok {
 		return sizeToBytes(value, multiplier, raw)
 	}
@@ -703,6 +1086,29 @@ func parseSizeToBytes(raw string) (int64, error) {
 	return 0, fmt.Errorf("invalid size unit %q", unit)
 }
 
+func isBlockSizeUnit(unit string) bool {
+	switch strings.ToUpper(unit) {
+	case "BLOCKS", "BLOCK", "BLK":
+		return true
+	}
+	return false
+}
+
+// volumeSizeParameter returns the size to pass to `create volume`. The array
+// has no block unit, so block counts are sent as bytes; other sizes are
+// passed through for the array to validate.
+func volumeSizeParameter(size string) (string, error) {
+	unit := regexp.MustCompile(`[A-Za-z]+$`).FindString(strings.TrimSpace(size))
+	if !isBlockSizeUnit(unit) {
+		return size, nil
+	}
+	bytes, err := parseSizeToBytes(size)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%dB", bytes), nil
+}
+
 func sizeToBytes(value, multiplier float64, raw string) (int64, error) {
 	result := value * multiplier
 	if math.IsNaN(result) || math.IsInf(result, 0) || result > float64(math.MaxInt64) {
@@ -2,8 +2,10 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -12,6 +14,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -31,14 +35,23 @@ type volumeMappingResource struct {
 }
 
 type volumeMappingResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	VolumeName types.String `tfsdk:"volume_name"`
-	TargetType types.String `tfsdk:"target_type"`
-	TargetName types.String `tfsdk:"target_name"`
-	Access     types.String `tfsdk:"access"`
-	LUN        types.String `tfsdk:"lun"`
-	Ports      types.Set    `tfsdk:"ports"`
-	Properties types.Map    `tfsdk:"properties"`
+	ID         types.String     `tfsdk:"id"`
+	VolumeName types.String     `tfsdk:"volume_name"`
+	TargetType types.String     `tfsdk:"target_type"`
+	TargetName types.String     `tfsdk:"target_name"`
+	Access     types.String     `tfsdk:"access"`
+	LUN        types.String     `tfsdk:"lun"`
+	Ports      types.Set        `tfsdk:"ports"`
+	PortLUNs   types.Map        `tfsdk:"port_luns"`
+	Manifest   types.String     `tfsdk:"manifest"`
+	Properties types.Map        `tfsdk:"properties"`
+	Connection *connectionModel `tfsdk:"connection"`
+
+	MemberLUNOverrides types.Map `tfsdk:"member_lun_overrides"`
+
+	CheckActiveSessions types.Bool `tfsdk:"check_active_sessions"`
+	Force               types.Bool `tfsdk:"force"`
+	SkipVolumeCheck     types.Bool `tfsdk:"skip_volume_check"`
 }
 
 func (r *volumeMappingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -74,7 +87,7 @@ func (r *volumeMappingResource) Schema(_ context.Context, _ resource.SchemaReque
 				},
 			},
 			"access": schema.StringAttribute{
-				Description: "Access level: read-write (rw), read-only (ro), or no-access.",
+				Description: "Access level: read-write (rw), read-only (ro), or no-access. Defaults to the provider's default_mapping_access, or read-write when that is unset.",
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
@@ -96,12 +109,50 @@ func (r *volumeMappingResource) Schema(_ context.Context, _ resource.SchemaReque
 					setplanmodifier.RequiresReplace(),
 				},
 			},
+			"member_lun_overrides": schema.MapAttribute{
+				Description: "Host group mappings only: member host name to the LUN that host should see instead of the group LUN. Each entry is applied as a host-level `map volume` after the group mapping.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"check_active_sessions": schema.BoolAttribute{
+				Description: "Before unmapping, probe the volume's host connections and sessions and block the unmap (retryable) while any are active, so a LUN is not pulled from a live host. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"force": schema.BoolAttribute{
+				Description: "Unmap even when check_active_sessions finds active sessions. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"skip_volume_check": schema.BoolAttribute{
+				Description: "Skip the pre-flight check that volume_name exists before mapping. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"port_luns": schema.MapAttribute{
+				Description: "LUN presented on each controller port, as reported by the array. Differing values indicate an asymmetric mapping that breaks multipath.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"manifest": schema.StringAttribute{
+				Description: "JSON summary of what a host needs to attach the volume: volume, serial_number, scsi_wwn, lun, access, ports, and the resolved target.",
+				Computed:    true,
+			},
 			"properties": schema.MapAttribute{
 				Description: "Raw mapping properties returned by the XML API.",
 				Computed:    true,
 				ElementType: types.StringType,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"connection": connectionBlock(),
+		},
 	}
 }
 
@@ -125,6 +176,10 @@ func (r *volumeMappingResource) Create(ctx context.Context, req resource.CreateR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, plan.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
@@ -136,13 +191,27 @@ func (r *volumeMappingResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	targetSpec, diag := buildTargetSpec(plan.TargetType, plan.TargetName)
+	if !plan.SkipVolumeCheck.ValueBool() {
+		if _, err := findMappingVolume(ctx, r.client, volume); err != nil {
+			if errors.Is(err, errVolumeNotFound) {
+				resp.Diagnostics.AddError(
+					"Volume not found",
+					fmt.Sprintf("volume %q not found; create hpe_msa_volume first, or reference its name attribute so Terraform orders the mapping after it. Set skip_volume_check = true to skip this check.", volume),
+				)
+				return
+			}
+			resp.Diagnostics.AddError("Unable to verify volume", err.Error())
+			return
+		}
+	}
+
+	targetSpec, diag := r.resolveTargetSpec(ctx, plan.TargetType, plan.TargetName, true)
 	resp.Diagnostics.Append(diag...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	access, diag := normalizeAccess(plan.Access)
+	access, diag := mappingAccess(plan.Access, r.client.DefaultMappingAccess())
 	resp.Diagnostics.Append(diag...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -154,6 +223,12 @@ func (r *volumeMappingResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	overrides, diag := memberLUNOverridesFromModel(ctx, plan.TargetType, plan.MemberLUNOverrides)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	lun := strings.TrimSpace(plan.LUN.ValueString())
 	if access != "no-access" {
 		if lun == "" {
@@ -165,6 +240,10 @@ func (r *volumeMappingResource) Create(ctx context.Context, req resource.CreateR
 		resp.Diagnostics.AddError("Invalid configuration", "lun is required when ports are specified")
 		return
 	}
+	explicitPorts := len(ports) > 0
+	if len(ports) == 0 && lun != "" {
+		ports = r.defaultSASPorts(ctx, plan.TargetType.ValueString(), plan.TargetName.ValueString())
+	}
 
 	parts := []string{"map", "volume"}
 	if access != "" {
@@ -181,8 +260,32 @@ func (r *volumeMappingResource) Create(ctx context.Context, req resource.CreateR
 
 	_, err := r.client.Execute(ctx, parts...)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to map volume", err.Error())
-		return
+		if !isAlreadyMappedError(err) {
+			resp.Diagnostics.AddError("Unable to map volume", err.Error())
+			return
+		}
+		// A retried apply after an interrupted create finds its own mapping;
+		// adopt it when it matches, otherwise report what differs.
+		existing, findErr := r.findMapping(ctx, volume, targetSpec)
+		if findErr != nil {
+			resp.Diagnostics.AddError("Unable to map volume", fmt.Sprintf("%v (reading the existing mapping failed: %v)", err, findErr))
+			return
+		}
+		comparePorts := ports
+		if !explicitPorts {
+			comparePorts = nil
+		}
+		if diffs := mappingDifferences(existing, access, lun, comparePorts); len(diffs) > 0 {
+			resp.Diagnostics.AddError(
+				"Mapping conflict",
+				fmt.Sprintf("Volume %q is already mapped to %q with different settings: %s. Import the mapping or unmap it on the array, then run `terraform apply` again.", volume, targetSpec, strings.Join(diffs, "; ")),
+			)
+			return
+		}
+		tflog.Info(ctx, "volume already mapped identically; adopting existing mapping", map[string]any{
+			"volume": volume,
+			"target": targetSpec,
+		})
 	}
 
 	mapping, err := r.waitForMapping(ctx, volume, targetSpec)
@@ -191,12 +294,21 @@ func (r *volumeMappingResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	state, diag := mappingStateFromModel(ctx, plan, mapping)
+	volumeDetails, diag := r.lookupVolume(ctx, volume)
+	resp.Diagnostics.Append(diag...)
+	state, diag := mappingStateFromModel(ctx, plan, mapping, volumeDetails, targetSpec)
 	resp.Diagnostics.Append(diag...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	state.ID = types.StringValue(mappingID(volume, targetSpec))
+	appendAsymmetricLUNWarning(&resp.Diagnostics, volume, mapping.PortLUNs)
+
+	if err := applyMemberLUNOverrides(ctx, r.client, access, ports, volume, overrides); err != nil {
+		// Keep the group mapping in state so the failed resource is tainted
+		// and replaced rather than leaked.
+		resp.Diagnostics.AddError("Unable to apply member LUN overrides", err.Error())
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -207,6 +319,10 @@ func (r *volumeMappingResource) Read(ctx context.Context, req resource.ReadReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
@@ -218,7 +334,7 @@ func (r *volumeMappingResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	targetSpec, diag := buildTargetSpec(state.TargetType, state.TargetName)
+	targetSpec, diag := r.resolveTargetSpec(ctx, state.TargetType, state.TargetName, false)
 	resp.Diagnostics.Append(diag...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -234,18 +350,53 @@ func (r *volumeMappingResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	newState, diag := mappingStateFromModel(ctx, state, mapping)
+	volumeDetails, diag := r.lookupVolume(ctx, volume)
+	resp.Diagnostics.Append(diag...)
+	newState, diag := mappingStateFromModel(ctx, state, mapping, volumeDetails, targetSpec)
 	resp.Diagnostics.Append(diag...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	newState.ID = types.StringValue(mappingID(volume, targetSpec))
+	appendAsymmetricLUNWarning(&resp.Diagnostics, volume, mapping.PortLUNs)
+
+	if !state.MemberLUNOverrides.IsNull() && !state.MemberLUNOverrides.IsUnknown() {
+		overrides := make(map[string]string)
+		resp.Diagnostics.Append(state.MemberLUNOverrides.ElementsAs(ctx, &overrides, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		current, err := readMemberLUNOverrides(ctx, r.client, volume, overrides)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read member LUN overrides", err.Error())
+			return
+		}
+		newState.MemberLUNOverrides, diag = memberLUNOverridesValue(ctx, current)
+		resp.Diagnostics.Append(diag...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
 }
 
+// Update only records the guard flags and the connection credentials; every
+// mapping parameter and the connection endpoint force replacement.
 func (r *volumeMappingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError("Update not supported", "Change volume_name, target, or mapping parameters by recreating the resource.")
+	var plan volumeMappingResourceModel
+	var state volumeMappingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Connection = plan.Connection
+	state.CheckActiveSessions = plan.CheckActiveSessions
+	state.Force = plan.Force
+	state.SkipVolumeCheck = plan.SkipVolumeCheck
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *volumeMappingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -254,6 +405,10 @@ func (r *volumeMappingResource) Delete(ctx context.Context, req resource.DeleteR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(applyConnection(&r.client, state.Connection)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if r.client == nil {
 		resp.Diagnostics.AddError("Provider not configured", "Missing MSA client")
 		return
@@ -265,32 +420,73 @@ func (r *volumeMappingResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
-	targetSpec, diag := buildTargetSpec(state.TargetType, state.TargetName)
-	resp.Diagnostics.Append(diag...)
+	targetSpec, targetDiags := r.resolveTargetSpec(ctx, state.TargetType, state.TargetName, false)
+	resp.Diagnostics.Append(targetDiags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	lockOwner := fmt.Sprintf("volume_mapping:%s:%s", targetSpec, volume)
-	lock, err := acquireDestroyGlobalLock(ctx, lockOwner)
-	if err != nil {
-		resp.Diagnostics.AddError("Unable to acquire destroy global lock", err.Error())
-		return
-	}
-	defer func() {
-		if releaseErr := lock.Release(ctx); releaseErr != nil {
-			tflog.Warn(ctx, "release MSA destroy global lock failed", map[string]any{
-				"lock_owner": lockOwner,
-				"error":      releaseErr.Error(),
-			})
+	resp.Diagnostics.Append(withDestroyLock(ctx, lockOwner, func() diag.Diagnostics {
+		var diags diag.Diagnostics
+		if state.CheckActiveSessions.ValueBool() {
+			if summary, detail, blocked := activeSessionUnmapGuard(ctx, r.client, volume, state.Force.ValueBool()); blocked {
+				diags.AddError(summary, detail)
+				return diags
+			}
+		}
+
+		if !state.MemberLUNOverrides.IsNull() && !state.MemberLUNOverrides.IsUnknown() {
+			overrides := make(map[string]string)
+			diags.Append(state.MemberLUNOverrides.ElementsAs(ctx, &overrides, false)...)
+			if diags.HasError() {
+				return diags
+			}
+			if err := removeMemberLUNOverrides(ctx, r.client, volume, overrides); err != nil {
+				diags.AddError("Unable to unmap volume", err.Error())
+				return diags
+			}
+		}
+
+		if err := deleteTolerant(ctx, r.client, "unmap", "volume", "initiator", targetSpec, volume); err != nil {
+			diags.AddError("Unable to unmap volume", err.Error())
 		}
-	}()
+		return diags
+	})...)
+}
 
-	_, err = r.client.Execute(ctx, "unmap", "volume", "initiator", targetSpec, volume)
+// activeSessionUnmapGuard probes the volume's host connections and sessions
+// before an unmap. It blocks while any are active unless force is set; a
+// failed probe only logs, matching the volume delete guardrails.
+func activeSessionUnmapGuard(ctx context.Context, client volumeDeleteProbeClient, volume string, force bool) (string, string, bool) {
+	identities := volumeIdentityHints(volume)
+	count, command, err := probeActiveVolumeConnections(ctx, client, identities)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to unmap volume", err.Error())
-		return
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return "Unmap interrupted", withDeleteClassification(true, fmt.Sprintf("Active session probe was interrupted before the unmap could continue: %v", err)), true
+		}
+		tflog.Warn(ctx, "Active session probe failed before unmap; continuing", map[string]any{
+			"volume": volume,
+			"error":  err.Error(),
+		})
+	}
+	if count == 0 {
+		return "", "", false
 	}
+	if force {
+		tflog.Warn(ctx, "Unmapping volume with active sessions because force is set", map[string]any{
+			"volume":   volume,
+			"sessions": count,
+			"command":  command,
+		})
+		return "", "", false
+	}
+	return "Unmap blocked: active sessions", withDeleteClassification(true, fmt.Sprintf(
+		"Volume %q still has active host/initiator connection %s (detected via `%s`). Quiesce I/O and log out the hosts, then run `terraform apply` again, or set force = true to unmap anyway.",
+		volume,
+		pluralize(count, "entry", "entries"),
+		command,
+	)), true
 }
 
 func (r *volumeMappingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
@@ -302,28 +498,207 @@ func (r *volumeMappingResource) ImportState(ctx context.Context, req resource.Im
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("volume_name"), parts[0])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("target_type"), parts[1])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("target_name"), parts[2])...)
+	setImportDefaults(ctx, resp, "check_active_sessions", "force", "skip_volume_check")
 }
 
 var errMappingNotFound = errors.New("mapping not found")
+var errInitiatorNicknameNotFound = errors.New("initiator nickname not found")
+
+// resolveTargetSpec builds the initiator spec for the mapping target. For
+// initiator targets given as a nickname, the nickname is resolved to the
+// initiator ID via `show initiators` because the map/unmap commands do not
+// reliably accept nicknames. When strict is false (read/delete), a nickname
+// no initiator carries any more is passed through unchanged; a failed lookup
+// or an ambiguous nickname is always an error, since guessing would drop a
+// live mapping from state or leave it on the array.
+func (r *volumeMappingResource) resolveTargetSpec(ctx context.Context, targetType, targetName types.String, strict bool) (string, diag.Diagnostics) {
+	targetSpec, diags := buildTargetSpec(targetType, targetName)
+	if diags.HasError() || strings.TrimSpace(targetType.ValueString()) != "initiator" || isValidInitiatorID(targetSpec) {
+		return targetSpec, diags
+	}
+
+	response, err := r.client.Execute(ctx, "show", "initiators")
+	if err != nil {
+		diags.AddError("Unable to resolve initiator nickname", err.Error())
+		return "", diags
+	}
+
+	id, err := resolveInitiatorNickname(msa.InitiatorsFromResponse(response), targetSpec)
+	if err != nil {
+		if !strict && errors.Is(err, errInitiatorNicknameNotFound) {
+			tflog.Warn(ctx, "initiator nickname no longer exists; using it verbatim", map[string]any{
+				"nickname": targetSpec,
+			})
+			return targetSpec, diags
+		}
+		diags.AddError("Unable to resolve initiator nickname", err.Error())
+		return "", diags
+	}
+	return id, diags
+}
+
+func resolveInitiatorNickname(initiators []msa.Initiator, nickname string) (string, error) {
+	nickname = strings.TrimSpace(nickname)
+	if isValidInitiatorID(nickname) {
+		return nickname, nil
+	}
+
+	matches := make([]string, 0, 1)
+	for _, initiator := range initiators {
+		if initiator.ID != "" && strings.EqualFold(initiator.Nickname, nickname) {
+			matches = append(matches, initiator.ID)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("%w: no initiator with nickname %q was returned by the array", errInitiatorNicknameNotFound, nickname)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("nickname %q matches %d initiators (%s); use the initiator ID", nickname, len(matches), strings.Join(matches, ", "))
+	}
+}
 
 func (r *volumeMappingResource) findMapping(ctx context.Context, volume, targetSpec string) (*msa.Mapping, error) {
-	response, err := r.client.Execute(ctx, "show", "maps", "initiator", targetSpec)
+	return lookupMapping(ctx, r.client, volume, targetSpec)
+}
+
+// lookupMapping prefers the volume-keyed view, which only lists the targets
+// of one volume and stays complete when the initiator-keyed view omits rows
+// inherited through hosts or host groups. When the array answers it, that
+// view is authoritative and a missing target is errMappingNotFound after a
+// single call; only firmware that rejects the volume view falls back to
+// `show maps initiator`.
+func lookupMapping(ctx context.Context, client commandExecutor, volume, targetSpec string) (*msa.Mapping, error) {
+	mapping, err := findMappingByVolume(ctx, client, volume, targetSpec)
+	if err == nil || errors.Is(err, errMappingNotFound) {
+		return mapping, err
+	}
+	tflog.Debug(ctx, "volume-keyed mapping lookup failed; falling back to initiator view", map[string]any{
+		"volume": volume,
+		"target": targetSpec,
+		"error":  err.Error(),
+	})
+	return findMappingByInitiator(ctx, client, volume, targetSpec)
+}
+
+func findMappingByVolume(ctx context.Context, client commandExecutor, volume, targetSpec string) (*msa.Mapping, error) {
+	response, err := client.Execute(ctx, "show", "maps", "volume", volume)
 	if err != nil {
 		return nil, err
 	}
 
+	matches := make([]msa.Mapping, 0)
+	for _, mapping := range msa.MappingsByVolumeFromResponse(response) {
+		if strings.EqualFold(mapping.Volume, volume) && mappingTargetMatches(mapping, targetSpec) {
+			matches = append(matches, mapping)
+		}
+	}
+	return mergeMappingRows(matches)
+}
+
+func findMappingByInitiator(ctx context.Context, client commandExecutor, volume, targetSpec string) (*msa.Mapping, error) {
+	response, err := client.Execute(ctx, "show", "maps", "initiator", targetSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]msa.Mapping, 0)
 	for _, mapping := range msa.MappingsFromResponse(response) {
 		if strings.EqualFold(mapping.Volume, volume) {
-			return &mapping, nil
+			matches = append(matches, mapping)
 		}
 	}
+	return mergeMappingRows(matches)
+}
 
-	return nil, errMappingNotFound
+func mergeMappingRows(matches []msa.Mapping) (*msa.Mapping, error) {
+	if len(matches) == 0 {
+		return nil, errMappingNotFound
+	}
+
+	// Asymmetric mappings are reported as one row per LUN; keep the first row
+	// for the flat fields and merge the per-port view across all of them.
+	mapping := matches[0]
+	mapping.PortLUNs = msa.MappingPortLUNs(matches)
+	return &mapping, nil
+}
+
+// mappingTargetMatches compares a volume-keyed row with the target spec,
+// keeping the target kind: "name.*.*" is a host group, "name.*" a host and a
+// bare name an initiator, so a host and a host group sharing a name never
+// match each other. Some firmware reports rows without their ".*" suffixes;
+// such a bare row matches the name for any kind.
+func mappingTargetMatches(mapping msa.Mapping, targetSpec string) bool {
+	wantName, wantKind := splitTargetSpec(targetSpec)
+	if wantName == "" {
+		return false
+	}
+	for _, candidate := range []string{mapping.Target, mapping.Properties["identifier"]} {
+		name, kind := splitTargetSpec(candidate)
+		if name == "" || !strings.EqualFold(name, wantName) {
+			continue
+		}
+		if kind == 0 || kind == wantKind {
+			return true
+		}
+	}
+	return false
+}
+
+// splitTargetSpec separates a target spec into its name and the number of
+// ".*" suffixes: 0 for an initiator, 1 for a host, 2 for a host group.
+func splitTargetSpec(value string) (string, int) {
+	value = strings.TrimSpace(value)
+	kind := 0
+	for strings.HasSuffix(value, ".*") {
+		value = strings.TrimSuffix(value, ".*")
+		kind++
+	}
+	return value, kind
+}
+
+// lookupVolume fetches the mapped volume for the manifest. A failure does
+// not fail the apply or refresh; it leaves the volume-derived manifest fields
+// (serial_number, scsi_wwn) empty and says so in a warning.
+func (r *volumeMappingResource) lookupVolume(ctx context.Context, name string) (*msa.Volume, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	volume, err := findMappingVolume(ctx, r.client, name)
+	if err != nil {
+		diags.AddWarning(
+			"Mapping manifest incomplete",
+			fmt.Sprintf("Unable to read volume %q for the mapping manifest, so its scsi_wwn is empty until the next successful refresh: %v", name, err),
+		)
+		return nil, diags
+	}
+	return volume, diags
+}
+
+// findMappingVolume reads the volume a mapping refers to by name. Create uses
+// it to confirm the volume exists before mapping, so a misspelled volume_name
+// fails with errVolumeNotFound instead of the array's map error.
+func findMappingVolume(ctx context.Context, client commandExecutor, name string) (*msa.Volume, error) {
+	response, err := client.Execute(ctx, "show", "volumes", name)
+	if err != nil {
+		if isNotFoundUsageProbeError(err) {
+			return nil, errVolumeNotFound
+		}
+		return nil, err
+	}
+	for _, volume := range msa.VolumesFromResponse(response) {
+		if strings.EqualFold(volume.Name, name) {
+			return &volume, nil
+		}
+	}
+	return nil, errVolumeNotFound
 }
 
 func (r *volumeMappingResource) waitForMapping(ctx context.Context, volume, targetSpec string) (*msa.Mapping, error) {
 	waits := []time.Duration{1 * time.Second, 2 * time.Second, 3 * time.Second}
 	for i, wait := range waits {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		mapping, err := r.findMapping(ctx, volume, targetSpec)
 		if err == nil {
 			return mapping, nil
@@ -342,6 +717,96 @@ func (r *volumeMappingResource) waitForMapping(ctx context.Context, volume, targ
 	return nil, errMappingNotFound
 }
 
+// defaultSASPorts narrows an unconstrained mapping to the controller ports
+// cabled to the target's SAS initiators so a LUN is not presented on every
+// port. It returns nil (map on all ports) whenever connectivity is unknown.
+func (r *volumeMappingResource) defaultSASPorts(ctx context.Context, targetType, targetName string) []string {
+	targetType = strings.TrimSpace(targetType)
+	if targetType != "initiator" && targetType != "host" {
+		return nil
+	}
+
+	response, err := r.client.Execute(ctx, "show", "initiators")
+	if err != nil {
+		tflog.Debug(ctx, "SAS port defaulting skipped: unable to list initiators", map[string]any{"error": err.Error()})
+		return nil
+	}
+	initiators := msa.InitiatorsFromResponse(response)
+
+	var hosts []msa.Host
+	if targetType == "host" {
+		response, err = r.client.Execute(ctx, "show", "host-groups")
+		if err != nil {
+			tflog.Debug(ctx, "SAS port defaulting skipped: unable to list hosts", map[string]any{"error": err.Error()})
+			return nil
+		}
+		hosts = msa.HostsFromResponse(response)
+	}
+
+	ports := sasConnectedPorts(initiators, hosts, targetType, targetName)
+	if len(ports) > 0 {
+		tflog.Info(ctx, "defaulting SAS mapping to connected ports", map[string]any{
+			"target_type": targetType,
+			"target_name": targetName,
+			"ports":       strings.Join(ports, ","),
+		})
+	}
+	return ports
+}
+
+func sasConnectedPorts(initiators []msa.Initiator, hosts []msa.Host, targetType, targetName string) []string {
+	targetName = strings.TrimSpace(targetName)
+	matched := make([]msa.Initiator, 0)
+
+	switch targetType {
+	case "initiator":
+		wanted := strings.ToLower(compactIdentity(targetName))
+		for _, initiator := range initiators {
+			if strings.ToLower(compactIdentity(initiator.ID)) == wanted || strings.EqualFold(initiator.Nickname, targetName) {
+				matched = append(matched, initiator)
+			}
+		}
+	case "host":
+		for _, host := range hosts {
+			if !strings.EqualFold(host.Name, targetName) {
+				continue
+			}
+			for i := range initiators {
+				if initiatorMatchesHost(&initiators[i], host) {
+					matched = append(matched, initiators[i])
+				}
+			}
+			break
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	ports := make([]string, 0)
+	for _, initiator := range matched {
+		if !initiator.IsSAS() {
+			return nil
+		}
+		connected := initiator.ConnectedPorts()
+		if len(connected) == 0 {
+			return nil
+		}
+		for _, port := range connected {
+			if _, ok := seen[port]; ok {
+				continue
+			}
+			seen[port] = struct{}{}
+			ports = append(ports, port)
+		}
+	}
+
+	sort.Strings(ports)
+	return ports
+}
+
 func buildTargetSpec(targetType types.String, targetName types.String) (string, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if targetType.IsUnknown() || targetType.IsNull() {
@@ -373,6 +838,17 @@ func buildTargetSpec(targetType types.String, targetName types.String) (string,
 	}
 }
 
+// mappingAccess returns the access for a new mapping. A configured access is
+// authoritative; otherwise the provider default applies, then read-write.
+func mappingAccess(value types.String, providerDefault string) (string, diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() || strings.TrimSpace(value.ValueString()) == "" {
+		if strings.TrimSpace(providerDefault) != "" {
+			return normalizeAccess(types.StringValue(providerDefault))
+		}
+	}
+	return normalizeAccess(value)
+}
+
 func normalizeAccess(value types.String) (string, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	if value.IsNull() || value.IsUnknown() {
@@ -397,7 +873,7 @@ func normalizeAccess(value types.String) (string, diag.Diagnostics) {
 	}
 }
 
-func mappingStateFromModel(ctx context.Context, model volumeMappingResourceModel, mapping *msa.Mapping) (volumeMappingResourceModel, diag.Diagnostics) {
+func mappingStateFromModel(ctx context.Context, model volumeMappingResourceModel, mapping *msa.Mapping, volume *msa.Volume, targetSpec string) (volumeMappingResourceModel, diag.Diagnostics) {
 	state := model
 	var diags diag.Diagnostics
 
@@ -418,17 +894,8 @@ func mappingStateFromModel(ctx context.Context, model volumeMappingResourceModel
 	}
 
 	if !model.Ports.IsNull() && !model.Ports.IsUnknown() {
-		ports := strings.TrimSpace(mapping.Ports)
-		if ports != "" {
-			portItems := strings.Split(ports, ",")
-			cleaned := make([]string, 0, len(portItems))
-			for _, item := range portItems {
-				item = strings.TrimSpace(item)
-				if item != "" {
-					cleaned = append(cleaned, item)
-				}
-			}
-			setValue, diag := types.SetValueFrom(ctx, types.StringType, cleaned)
+		if ports := mapping.PortList(); len(ports) > 0 {
+			setValue, diag := types.SetValueFrom(ctx, types.StringType, ports)
 			if diag.HasError() {
 				diags.Append(diag...)
 				return state, diags
@@ -441,6 +908,24 @@ func mappingStateFromModel(ctx context.Context, model volumeMappingResourceModel
 		state.Ports = types.SetNull(types.StringType)
 	}
 
+	portLUNs := mapping.PortLUNs
+	if portLUNs == nil {
+		portLUNs = map[string]string{}
+	}
+	portLUNsValue, diag := types.MapValueFrom(ctx, types.StringType, portLUNs)
+	if diag.HasError() {
+		diags.Append(diag...)
+		return state, diags
+	}
+	state.PortLUNs = portLUNsValue
+
+	manifest, err := buildMappingManifest(state, mapping, volume, targetSpec)
+	if err != nil {
+		diags.AddError("Unable to build mapping manifest", err.Error())
+		return state, diags
+	}
+	state.Manifest = types.StringValue(manifest)
+
 	propsValue, diag := types.MapValueFrom(ctx, types.StringType, mapping.Properties)
 	if diag.HasError() {
 		diags.Append(diag...)
@@ -451,6 +936,105 @@ func mappingStateFromModel(ctx context.Context, model volumeMappingResourceModel
 	return state, diags
 }
 
+type mappingManifest struct {
+	Volume       string   `json:"volume"`
+	SerialNumber string   `json:"serial_number"`
+	SCSIWWN      string   `json:"scsi_wwn"`
+	LUN          string   `json:"lun"`
+	Access       string   `json:"access"`
+	Ports        []string `json:"ports"`
+	TargetType   string   `json:"target_type"`
+	TargetName   string   `json:"target_name"`
+	TargetSpec   string   `json:"target_spec"`
+}
+
+// buildMappingManifest renders the attachment details a host needs as JSON
+// so orchestration does not have to dig through raw state.
+func buildMappingManifest(state volumeMappingResourceModel, mapping *msa.Mapping, volume *msa.Volume, targetSpec string) (string, error) {
+	manifest := mappingManifest{
+		Volume:       mapping.Volume,
+		SerialNumber: mapping.VolumeSerial,
+		LUN:          state.LUN.ValueString(),
+		Access:       state.Access.ValueString(),
+		Ports:        mapping.PortList(),
+		TargetType:   state.TargetType.ValueString(),
+		TargetName:   state.TargetName.ValueString(),
+		TargetSpec:   targetSpec,
+	}
+	sort.Strings(manifest.Ports)
+	if volume != nil {
+		manifest.SerialNumber = firstNonEmpty(manifest.SerialNumber, volume.SerialNumber)
+		manifest.SCSIWWN = volume.WWN
+	}
+
+	encoded, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// appendAsymmetricLUNWarning flags mappings whose ports present different
+// LUNs; the flat lun attribute can only carry one of them.
+func appendAsymmetricLUNWarning(diags *diag.Diagnostics, volume string, portLUNs map[string]string) {
+	if !msa.HasAsymmetricLUNs(portLUNs) {
+		return
+	}
+	diags.AddWarning(
+		"Asymmetric LUN mapping",
+		fmt.Sprintf("Volume %q is presented with different LUNs (%s) on different ports; see port_luns. Host multipath expects the same LUN on every path, so remap the volume with a single LUN.", volume, strings.Join(msa.DistinctLUNs(portLUNs), ", ")),
+	)
+}
+
+func isAlreadyMappedError(err error) bool {
+	var apiErr msa.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	message := strings.ToLower(apiErr.Status.Response)
+	if strings.Contains(message, "already mapped") {
+		return true
+	}
+	// "already exists" alone also covers unrelated objects (a LUN, a host
+	// name); only count it when the message is about the mapping itself.
+	return strings.Contains(message, "already exists") && strings.Contains(message, "mapping")
+}
+
+// mappingDifferences lists how an existing mapping differs from the desired
+// access, LUN, and ports. An empty lun or nil ports is not compared.
+func mappingDifferences(existing *msa.Mapping, access, lun string, ports []string) []string {
+	diffs := make([]string, 0)
+	if current := canonicalAccess(existing.Access); current != "" && current != access {
+		diffs = append(diffs, fmt.Sprintf("access is %q, want %q", current, access))
+	}
+	if lun != "" && strings.TrimSpace(existing.LUN) != lun {
+		diffs = append(diffs, fmt.Sprintf("lun is %q, want %q", existing.LUN, lun))
+	}
+	if len(ports) > 0 {
+		current := normalizedPortList(existing.PortList())
+		want := normalizedPortList(ports)
+		if strings.Join(current, ",") != strings.Join(want, ",") {
+			have := strings.Join(current, ",")
+			if have == "" {
+				have = "all ports"
+			}
+			diffs = append(diffs, fmt.Sprintf("ports are %q, want %q", have, strings.Join(want, ",")))
+		}
+	}
+	return diffs
+}
+
+func normalizedPortList(ports []string) []string {
+	normalized := make([]string, 0, len(ports))
+	for _, port := range ports {
+		if port = strings.ToUpper(strings.TrimSpace(port)); port != "" {
+			normalized = append(normalized, port)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
 func canonicalAccess(value string) string {
 	value = strings.TrimSpace(strings.ToLower(value))
 	switch value {
@@ -2,10 +2,20 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestBuildTargetSpec(t *testing.T) {
@@ -66,6 +76,92 @@ func TestNormalizeAccessInvalid(t *testing.T) {
 	}
 }
 
+func TestMappingAccessDefaultPrecedence(t *testing.T) {
+	cases := []struct {
+		name            string
+		access          types.String
+		providerDefault string
+		want            string
+	}{
+		{name: "no default", access: types.StringNull(), want: "read-write"},
+		{name: "provider default", access: types.StringNull(), providerDefault: "no-access", want: "no-access"},
+		{name: "unknown uses default", access: types.StringUnknown(), providerDefault: "ro", want: "read-only"},
+		{name: "blank uses default", access: types.StringValue(" "), providerDefault: "no-access", want: "no-access"},
+		{name: "explicit wins", access: types.StringValue("rw"), providerDefault: "no-access", want: "read-write"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, diags := mappingAccess(tc.access, tc.providerDefault)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+const mappingTestVolumeResponse = `<RESPONSE VERSION="L100"><OBJECT basetype="volumes" name="volume"><PROPERTY name="volume-name">vol-a</PROPERTY>` +
+	`<PROPERTY name="serial-number">00c0ff0000000000000000000000000a</PROPERTY></OBJECT></RESPONSE>`
+
+func TestVolumeMappingCreateAppliesProviderDefaultAccess(t *testing.T) {
+	const initiator = "iqn.1991-05.com.example:host-a"
+	server, paths := newMSATestServer(t, func(path string) string {
+		if path == "/api/show/volumes/vol-a" {
+			return mappingTestVolumeResponse
+		}
+		if path == "/api/show/maps/volume/vol-a" {
+			return `<RESPONSE VERSION="L100"><OBJECT basetype="volume-view" name="volume-view"><PROPERTY name="volume-name">vol-a</PROPERTY>` +
+				`<OBJECT basetype="volume-view-mappings" name="volume-view-mapping"><PROPERTY name="mapped-id">` + initiator + `</PROPERTY>` +
+				`<PROPERTY name="access">no-access</PROPERTY><PROPERTY name="lun"></PROPERTY><PROPERTY name="ports"></PROPERTY></OBJECT></OBJECT></RESPONSE>`
+		}
+		return `<RESPONSE VERSION="L100"></RESPONSE>`
+	})
+	client, err := msa.NewClient(msa.Config{
+		Endpoint:             server.URL,
+		Username:             "user",
+		Password:             "pass",
+		InsecureTLS:          true,
+		DefaultMappingAccess: "no-access",
+	})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	r := &volumeMappingResource{client: client}
+	state := resourceState(t, r, map[string]tftypes.Value{
+		"volume_name": tftypes.NewValue(tftypes.String, "vol-a"),
+		"target_type": tftypes.NewValue(tftypes.String, "initiator"),
+		"target_name": tftypes.NewValue(tftypes.String, initiator),
+		"access":      tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+	})
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	wantMap := "/api/map/volume/access/no-access/initiator/" + initiator + "/vol-a"
+	found := false
+	for _, path := range *paths {
+		if path == wantMap {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected %s, got %v", wantMap, *paths)
+	}
+
+	var got volumeMappingResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &got)...)
+	if got.Access.ValueString() != "no-access" {
+		t.Fatalf("expected no-access in state, got %v", got.Access)
+	}
+}
+
 func TestMappingStatePortsNullWhenUnconfigured(t *testing.T) {
 	ctx := context.Background()
 	model := volumeMappingResourceModel{
@@ -78,7 +174,7 @@ func TestMappingStatePortsNullWhenUnconfigured(t *testing.T) {
 		Ports:  "1,2,3",
 	}
 
-	state, diags := mappingStateFromModel(ctx, model, mapping)
+	state, diags := mappingStateFromModel(ctx, model, mapping, nil, "")
 	if diags.HasError() {
 		t.Fatalf("unexpected diagnostics: %v", diags)
 	}
@@ -103,7 +199,7 @@ func TestMappingStatePortsFromAPIWhenConfigured(t *testing.T) {
 		Ports:  "1,2,3",
 	}
 
-	state, diags := mappingStateFromModel(ctx, model, mapping)
+	state, diags := mappingStateFromModel(ctx, model, mapping, nil, "")
 	if diags.HasError() {
 		t.Fatalf("unexpected diagnostics: %v", diags)
 	}
@@ -120,6 +216,100 @@ func TestMappingStatePortsFromAPIWhenConfigured(t *testing.T) {
 	}
 }
 
+func TestMappingStatePortLUNsAsymmetric(t *testing.T) {
+	ctx := context.Background()
+	model := volumeMappingResourceModel{
+		Ports: types.SetNull(types.StringType),
+	}
+	mapping := &msa.Mapping{
+		Volume:   "vol1",
+		Access:   "read-write",
+		LUN:      "10",
+		Ports:    "A1,B1",
+		PortLUNs: map[string]string{"A1": "10", "B1": "10", "A2": "11", "B2": "11"},
+	}
+
+	state, diags := mappingStateFromModel(ctx, model, mapping, nil, "")
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	var portLUNs map[string]string
+	diags = state.PortLUNs.ElementsAs(ctx, &portLUNs, false)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics reading port_luns: %v", diags)
+	}
+	if len(portLUNs) != 4 || portLUNs["A2"] != "11" {
+		t.Fatalf("unexpected port_luns %v", portLUNs)
+	}
+
+	appendAsymmetricLUNWarning(&diags, "vol1", mapping.PortLUNs)
+	if diags.WarningsCount() != 1 {
+		t.Fatalf("expected one asymmetric LUN warning, got %v", diags)
+	}
+	if !strings.Contains(diags.Warnings()[0].Detail(), "10, 11") {
+		t.Fatalf("expected warning to list LUNs, got %q", diags.Warnings()[0].Detail())
+	}
+
+	var symmetric diag.Diagnostics
+	appendAsymmetricLUNWarning(&symmetric, "vol1", map[string]string{"A1": "10", "B1": "10"})
+	if len(symmetric) != 0 {
+		t.Fatalf("expected no warning for symmetric LUNs, got %v", symmetric)
+	}
+}
+
+func TestMappingStateManifest(t *testing.T) {
+	ctx := context.Background()
+	model := volumeMappingResourceModel{
+		TargetType: types.StringValue("host"),
+		TargetName: types.StringValue("pve1"),
+		Ports:      types.SetNull(types.StringType),
+	}
+	mapping := &msa.Mapping{
+		Volume:       "vol01",
+		VolumeSerial: "00c0ff3cab9c00000000000002010000",
+		Access:       "rw",
+		LUN:          "10",
+		Ports:        "B1, A1",
+	}
+	volume := &msa.Volume{
+		Name:         "vol01",
+		SerialNumber: "00c0ff3cab9c00000000000002010000",
+		WWN:          "600c0ff0003cab9c0000000002010000",
+	}
+
+	state, diags := mappingStateFromModel(ctx, model, mapping, volume, "pve1.*")
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	var manifest mappingManifest
+	if err := json.Unmarshal([]byte(state.Manifest.ValueString()), &manifest); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+	want := mappingManifest{
+		Volume:       "vol01",
+		SerialNumber: "00c0ff3cab9c00000000000002010000",
+		SCSIWWN:      "600c0ff0003cab9c0000000002010000",
+		LUN:          "10",
+		Access:       "read-write",
+		Ports:        []string{"A1", "B1"},
+		TargetType:   "host",
+		TargetName:   "pve1",
+		TargetSpec:   "pve1.*",
+	}
+	if !reflect.DeepEqual(manifest, want) {
+		t.Fatalf("unexpected manifest\n got: %+v\nwant: %+v", manifest, want)
+	}
+
+	state, diags = mappingStateFromModel(ctx, model, mapping, nil, "pve1.*")
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !strings.Contains(state.Manifest.ValueString(), `"scsi_wwn":""`) {
+		t.Fatalf("expected empty scsi_wwn without volume lookup, got %s", state.Manifest.ValueString())
+	}
+}
+
 func TestCanonicalAccess(t *testing.T) {
 	cases := map[string]string{
 		"rw":         "read-write",
@@ -135,3 +325,631 @@ func TestCanonicalAccess(t *testing.T) {
 		}
 	}
 }
+
+func TestSASConnectedPorts(t *testing.T) {
+	sasInitiator := func(id, nickname, hostKey, bitsA, bitsB string) msa.Initiator {
+		return msa.Initiator{
+			ID:          id,
+			Nickname:    nickname,
+			HostKey:     hostKey,
+			HostBusType: "SAS",
+			Properties: map[string]string{
+				"host-port-bits-a": bitsA,
+				"host-port-bits-b": bitsB,
+			},
+		}
+	}
+
+	initiators := []msa.Initiator{
+		sasInitiator("500605b00d1a2b30", "pve1-sas0", "H1", "1", "1"),
+		sasInitiator("500605b00d1a2b31", "pve1-sas1", "H1", "2", "2"),
+		sasInitiator("500605b00d1a2b40", "pve2-sas0", "H2", "0", "0"),
+		{ID: "21000024ff3dd8a0", Nickname: "esx-fc0", HostKey: "H3", HostBusType: "FC", Properties: map[string]string{"host-port-bits-a": "1"}},
+	}
+	hosts := []msa.Host{
+		{Name: "pve1", DurableID: "H1"},
+		{Name: "pve2", DurableID: "H2"},
+		{Name: "esx", DurableID: "H3"},
+	}
+
+	testCases := []struct {
+		name       string
+		targetType string
+		targetName string
+		want       []string
+	}{
+		{name: "initiator by id", targetType: "initiator", targetName: "50:06:05:b0:0d:1a:2b:30", want: []string{"A1", "B1"}},
+		{name: "initiator by nickname", targetType: "initiator", targetName: "pve1-sas1", want: []string{"A2", "B2"}},
+		{name: "host unions member ports", targetType: "host", targetName: "PVE1", want: []string{"A1", "A2", "B1", "B2"}},
+		{name: "unknown connectivity falls back", targetType: "host", targetName: "pve2", want: nil},
+		{name: "non-sas falls back", targetType: "host", targetName: "esx", want: nil},
+		{name: "unknown initiator falls back", targetType: "initiator", targetName: "missing", want: nil},
+		{name: "host group unsupported", targetType: "host_group", targetName: "pve1", want: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := sasConnectedPorts(initiators, hosts, tc.targetType, tc.targetName)
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("expected %v, got %v", tc.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveInitiatorNickname(t *testing.T) {
+	initiators := []msa.Initiator{
+		{ID: "20000000000000c1", Nickname: "InitA"},
+		{ID: "iqn.1993-08.org.debian:01:abcdef", Nickname: "pve1-iscsi"},
+		{ID: "20000000000000c3", Nickname: "dup"},
+		{ID: "20000000000000c4", Nickname: "DUP"},
+	}
+
+	testCases := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "nickname resolves to wwpn", input: "InitA", want: "20000000000000c1"},
+		{name: "nickname case-insensitive", input: "PVE1-ISCSI", want: "iqn.1993-08.org.debian:01:abcdef"},
+		{name: "raw wwpn passes through", input: "20:00:00:00:00:00:00:c9", want: "20:00:00:00:00:00:00:c9"},
+		{name: "raw iqn passes through", input: "iqn.2005-03.org.open-iscsi:host1", want: "iqn.2005-03.org.open-iscsi:host1"},
+		{name: "unknown nickname", input: "missing", wantErr: true},
+		{name: "ambiguous nickname", input: "dup", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resolveInitiatorNickname(initiators, tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMemberLUNOverridesFromModel(t *testing.T) {
+	ctx := context.Background()
+	value := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"esx-b": types.StringValue("21"),
+		"esx-a": types.StringValue(" 20 "),
+	})
+
+	overrides, diags := memberLUNOverridesFromModel(ctx, types.StringValue("host_group"), value)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if !reflect.DeepEqual(overrides, map[string]string{"esx-a": "20", "esx-b": "21"}) {
+		t.Fatalf("unexpected overrides %v", overrides)
+	}
+
+	if _, diags := memberLUNOverridesFromModel(ctx, types.StringValue("host"), value); !diags.HasError() {
+		t.Fatalf("expected overrides to be rejected for host targets")
+	}
+
+	invalid := types.MapValueMust(types.StringType, map[string]attr.Value{"esx-a": types.StringValue("x1")})
+	if _, diags := memberLUNOverridesFromModel(ctx, types.StringValue("host_group"), invalid); !diags.HasError() {
+		t.Fatalf("expected non-numeric LUN to be rejected")
+	}
+
+	overrides, diags = memberLUNOverridesFromModel(ctx, types.StringValue("host"), types.MapNull(types.StringType))
+	if diags.HasError() || overrides != nil {
+		t.Fatalf("expected null overrides to be ignored, got %v %v", overrides, diags)
+	}
+}
+
+func TestApplyMemberLUNOverrides(t *testing.T) {
+	client := &recordingCommandClient{results: map[string][]error{}}
+	overrides := map[string]string{"esx-b": "21", "esx-a": "20"}
+
+	err := applyMemberLUNOverrides(context.Background(), client, "read-write", []string{"A1", "B1"}, "vol1", overrides)
+	if err != nil {
+		t.Fatalf("apply overrides: %v", err)
+	}
+	want := []string{
+		"map volume access read-write ports A1,B1 lun 20 initiator esx-a.* vol1",
+		"map volume access read-write ports A1,B1 lun 21 initiator esx-b.* vol1",
+	}
+	if !reflect.DeepEqual(client.calls, want) {
+		t.Fatalf("expected %v, got %v", want, client.calls)
+	}
+
+	failing := &recordingCommandClient{results: map[string][]error{
+		"map volume lun 20 initiator esx-a.* vol1": {msa.APIError{Status: msa.Status{Response: "The LUN is already in use."}}},
+	}}
+	err = applyMemberLUNOverrides(context.Background(), failing, "", nil, "vol1", overrides)
+	if err == nil || !strings.Contains(err.Error(), `host "esx-a"`) {
+		t.Fatalf("expected failing host in error, got %v", err)
+	}
+	if len(failing.calls) != 1 {
+		t.Fatalf("expected overrides to stop at the first failure, got %v", failing.calls)
+	}
+}
+
+func TestReadMemberLUNOverrides(t *testing.T) {
+	hostMapping := func(lun string) fakeVolumeDeleteProbeResult {
+		return fakeVolumeDeleteProbeResult{response: msa.Response{Objects: []msa.Object{{
+			BaseType: "host-view-mappings",
+			Properties: []msa.Property{
+				{Name: "volume", Value: "vol1"},
+				{Name: "access", Value: "read-write"},
+				{Name: "lun", Value: lun},
+				{Name: "ports", Value: "A1,B1"},
+			},
+		}}}}
+	}
+	client := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show maps initiator esx-a.*": hostMapping("25"),
+		"show maps initiator esx-b.*": {response: msa.Response{}},
+	}}
+
+	current, err := readMemberLUNOverrides(context.Background(), client, "vol1", map[string]string{"esx-a": "20", "esx-b": "21"})
+	if err != nil {
+		t.Fatalf("read overrides: %v", err)
+	}
+	if !reflect.DeepEqual(current, map[string]string{"esx-a": "25"}) {
+		t.Fatalf("expected drifted LUN and missing host dropped, got %v", current)
+	}
+}
+
+func TestLookupMappingPrefersVolumeView(t *testing.T) {
+	volumeView := msa.Response{Objects: []msa.Object{{
+		BaseType:   "volume-view",
+		Properties: []msa.Property{{Name: "volume-name", Value: "vol1"}},
+		Objects: []msa.Object{
+			{
+				BaseType: "volume-view-mappings",
+				Properties: []msa.Property{
+					{Name: "mapped-id", Value: "esx-a.*"},
+					{Name: "lun", Value: "20"},
+					{Name: "access", Value: "read-only"},
+					{Name: "ports", Value: "A1"},
+				},
+			},
+			{
+				BaseType: "volume-view-mappings",
+				Properties: []msa.Property{
+					{Name: "mapped-id", Value: "Cluster"},
+					{Name: "lun", Value: "10"},
+					{Name: "access", Value: "read-write"},
+					{Name: "ports", Value: "A1,B1"},
+				},
+			},
+		},
+	}}}
+	client := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show maps volume vol1": {response: volumeView},
+	}}
+
+	mapping, err := lookupMapping(context.Background(), client, "vol1", "Cluster.*.*")
+	if err != nil {
+		t.Fatalf("lookup mapping: %v", err)
+	}
+	if mapping.LUN != "10" || mapping.Access != "read-write" {
+		t.Fatalf("expected the host group row, got %+v", mapping)
+	}
+
+	mapping, err = lookupMapping(context.Background(), client, "vol1", "ESX-A.*")
+	if err != nil {
+		t.Fatalf("lookup host mapping: %v", err)
+	}
+	if mapping.LUN != "20" {
+		t.Fatalf("expected the host row, got %+v", mapping)
+	}
+
+	// A host group sharing the host's name is a different target.
+	if _, err := lookupMapping(context.Background(), client, "vol1", "ESX-A.*.*"); !errors.Is(err, errMappingNotFound) {
+		t.Fatalf("expected the host row not to match a host group spec, got %v", err)
+	}
+}
+
+func TestLookupMappingFallsBackToInitiatorView(t *testing.T) {
+	initiatorView := fakeVolumeDeleteProbeResult{response: msa.Response{Objects: []msa.Object{{
+		BaseType: "host-view-mappings",
+		Properties: []msa.Property{
+			{Name: "volume", Value: "vol1"},
+			{Name: "lun", Value: "7"},
+			{Name: "access", Value: "read-write"},
+		},
+	}}}}
+
+	unsupported := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show maps initiator esx-b.*": initiatorView,
+	}}
+	mapping, err := lookupMapping(context.Background(), unsupported, "vol1", "esx-b.*")
+	if err != nil {
+		t.Fatalf("expected fallback when the volume view is unsupported: %v", err)
+	}
+	if mapping.LUN != "7" {
+		t.Fatalf("unexpected mapping %+v", mapping)
+	}
+
+	// A volume view that answers is authoritative: a missing target is not
+	// looked up again in the initiator view.
+	missing := &recordingCommandClient{}
+	if _, err := lookupMapping(context.Background(), missing, "vol1", "esx-b.*"); !errors.Is(err, errMappingNotFound) {
+		t.Fatalf("expected errMappingNotFound, got %v", err)
+	}
+	if strings.Join(missing.calls, "|") != "show maps volume vol1" {
+		t.Fatalf("expected a single array call, got %v", missing.calls)
+	}
+}
+
+func TestActiveSessionUnmapGuard(t *testing.T) {
+	client := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show connections volume vol-a": {response: msa.Response{Objects: []msa.Object{{
+			BaseType: "connection",
+			Name:     "connection",
+			Properties: []msa.Property{
+				{Name: "volume-name", Value: "vol-a"},
+				{Name: "connection-status", Value: "Connected"},
+				{Name: "host-name", Value: "app-host-01"},
+			},
+		}}}},
+	}}
+
+	summary, detail, blocked := activeSessionUnmapGuard(context.Background(), client, "vol-a", false)
+	if !blocked {
+		t.Fatalf("expected active sessions to block the unmap")
+	}
+	if summary != "Unmap blocked: active sessions" || !strings.Contains(detail, "Classification: retryable") {
+		t.Fatalf("unexpected guard diagnostic: %s: %s", summary, detail)
+	}
+	if !strings.Contains(detail, "show connections volume vol-a") {
+		t.Fatalf("expected probe command in detail, got %s", detail)
+	}
+
+	if _, _, blocked := activeSessionUnmapGuard(context.Background(), client, "vol-a", true); blocked {
+		t.Fatalf("expected force to override the active session block")
+	}
+	if _, _, blocked := activeSessionUnmapGuard(context.Background(), client, "vol-b", false); blocked {
+		t.Fatalf("expected a volume without sessions to be unmapped")
+	}
+}
+
+func TestVolumeMappingDeleteChecksActiveSessions(t *testing.T) {
+	t.Setenv("HPE_MSA_DESTROY_GLOBAL_LOCK_DIR", filepath.Join(t.TempDir(), "destroy-lock.d"))
+
+	const initiator = "iqn.1991-05.com.example:host-a"
+	run := func(force bool) []string {
+		server, paths := newMSATestServer(t, func(path string) string {
+			if path == "/api/show/connections/volume/vol-a" {
+				return `<RESPONSE VERSION="L100"><OBJECT basetype="connection" name="connection"><PROPERTY name="volume-name">vol-a</PROPERTY><PROPERTY name="connection-status">Connected</PROPERTY></OBJECT></RESPONSE>`
+			}
+			return `<RESPONSE VERSION="L100"></RESPONSE>`
+		})
+		client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+		if err != nil {
+			t.Fatalf("create client: %v", err)
+		}
+		r := &volumeMappingResource{client: client}
+		state := resourceState(t, r, map[string]tftypes.Value{
+			"volume_name":           tftypes.NewValue(tftypes.String, "vol-a"),
+			"target_type":           tftypes.NewValue(tftypes.String, "initiator"),
+			"target_name":           tftypes.NewValue(tftypes.String, initiator),
+			"check_active_sessions": tftypes.NewValue(tftypes.Bool, true),
+			"force":                 tftypes.NewValue(tftypes.Bool, force),
+		})
+		resp := resource.DeleteResponse{State: state}
+		r.Delete(context.Background(), resource.DeleteRequest{State: state}, &resp)
+		if force && resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics with force: %v", resp.Diagnostics)
+		}
+		if !force && !resp.Diagnostics.HasError() {
+			t.Fatalf("expected the unmap to be blocked by active sessions")
+		}
+		return *paths
+	}
+
+	unmap := "/api/unmap/volume/initiator/" + initiator + "/vol-a"
+	for _, path := range run(false) {
+		if path == unmap {
+			t.Fatalf("unmap was sent despite active sessions")
+		}
+	}
+	found := false
+	for _, path := range run(true) {
+		found = found || path == unmap
+	}
+	if !found {
+		t.Fatalf("expected force to send %s", unmap)
+	}
+}
+
+func TestMappingDifferences(t *testing.T) {
+	existing := &msa.Mapping{Access: "read-write", LUN: "10", Ports: "A1,B1"}
+
+	if diffs := mappingDifferences(existing, "read-write", "10", []string{"b1", "a1"}); len(diffs) != 0 {
+		t.Fatalf("expected identical mapping, got %v", diffs)
+	}
+	if diffs := mappingDifferences(existing, "read-write", "10", nil); len(diffs) != 0 {
+		t.Fatalf("expected unconfigured ports to be ignored, got %v", diffs)
+	}
+
+	diffs := mappingDifferences(existing, "read-only", "11", []string{"A1"})
+	want := []string{`access is "read-write", want "read-only"`, `lun is "10", want "11"`, `ports are "A1,B1", want "A1"`}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Fatalf("unexpected differences:\n got %v\nwant %v", diffs, want)
+	}
+}
+
+func TestIsAlreadyMappedError(t *testing.T) {
+	testCases := []struct {
+		message string
+		want    bool
+	}{
+		{message: "Error: The volume is already mapped to the specified initiator.", want: true},
+		{message: "Error: A mapping already exists for the volume and host.", want: true},
+		{message: "Error: The specified LUN already exists for this host.", want: false},
+		{message: "Error: A host with that name already exists.", want: false},
+	}
+	for _, tc := range testCases {
+		if got := isAlreadyMappedError(limitAPIError(tc.message)); got != tc.want {
+			t.Fatalf("%q: expected %v, got %v", tc.message, tc.want, got)
+		}
+	}
+}
+
+func TestVolumeMappingCreateAlreadyMapped(t *testing.T) {
+	const initiator = "iqn.1991-05.com.example:host-a"
+	alreadyMapped := `<RESPONSE VERSION="L100"><OBJECT basetype="status" name="status"><PROPERTY name="response-type">Error</PROPERTY><PROPERTY name="response-type-numeric">1</PROPERTY>` +
+		`<PROPERTY name="response">Error: The volume is already mapped to the specified initiator.</PROPERTY><PROPERTY name="return-code">-10071</PROPERTY></OBJECT></RESPONSE>`
+
+	create := func(t *testing.T, existingLUN string) resource.CreateResponse {
+		server, _ := newMSATestServer(t, func(path string) string {
+			switch {
+			case strings.HasPrefix(path, "/api/map/volume"):
+				return alreadyMapped
+			case path == "/api/show/volumes/vol-a":
+				return mappingTestVolumeResponse
+			case path == "/api/show/maps/volume/vol-a":
+				return `<RESPONSE VERSION="L100"><OBJECT basetype="volume-view" name="volume-view"><PROPERTY name="volume-name">vol-a</PROPERTY>` +
+					`<OBJECT basetype="volume-view-mappings" name="volume-view-mapping"><PROPERTY name="mapped-id">` + initiator + `</PROPERTY>` +
+					`<PROPERTY name="access">read-write</PROPERTY><PROPERTY name="lun">` + existingLUN + `</PROPERTY><PROPERTY name="ports">A1,B1</PROPERTY></OBJECT></OBJECT></RESPONSE>`
+			}
+			return `<RESPONSE VERSION="L100"></RESPONSE>`
+		})
+		client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+		if err != nil {
+			t.Fatalf("create client: %v", err)
+		}
+
+		r := &volumeMappingResource{client: client}
+		state := resourceState(t, r, map[string]tftypes.Value{
+			"volume_name": tftypes.NewValue(tftypes.String, "vol-a"),
+			"target_type": tftypes.NewValue(tftypes.String, "initiator"),
+			"target_name": tftypes.NewValue(tftypes.String, initiator),
+			"access":      tftypes.NewValue(tftypes.String, "rw"),
+			"lun":         tftypes.NewValue(tftypes.String, "10"),
+			"ports": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "a1"),
+				tftypes.NewValue(tftypes.String, "b1"),
+			}),
+		})
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+		r.Create(context.Background(), resource.CreateRequest{Plan: tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}}, &resp)
+		return resp
+	}
+
+	t.Run("adopts identical mapping", func(t *testing.T) {
+		resp := create(t, "10")
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("expected identical mapping to be adopted, got %v", resp.Diagnostics)
+		}
+		var got volumeMappingResourceModel
+		resp.Diagnostics.Append(resp.State.Get(context.Background(), &got)...)
+		if got.LUN.ValueString() != "10" || got.ID.ValueString() != "vol-a:"+initiator {
+			t.Fatalf("unexpected adopted state: %+v", got)
+		}
+	})
+
+	t.Run("reports conflicting mapping", func(t *testing.T) {
+		resp := create(t, "12")
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected a conflict diagnostic")
+		}
+		detail := resp.Diagnostics.Errors()[0].Detail()
+		if resp.Diagnostics.Errors()[0].Summary() != "Mapping conflict" || !strings.Contains(detail, `lun is "12", want "10"`) {
+			t.Fatalf("unexpected diagnostic: %v", resp.Diagnostics)
+		}
+	})
+}
+
+func TestVolumeMappingCreateChecksVolumeExists(t *testing.T) {
+	const initiator = "iqn.1991-05.com.example:host-a"
+	missingVolume := `<RESPONSE VERSION="L100"><OBJECT basetype="status" name="status"><PROPERTY name="response-type">Error</PROPERTY><PROPERTY name="response-type-numeric">1</PROPERTY>` +
+		`<PROPERTY name="response">Error: The specified volume was not found.</PROPERTY><PROPERTY name="return-code">-10008</PROPERTY></OBJECT></RESPONSE>`
+
+	create := func(t *testing.T, showVolume string, skip bool) (resource.CreateResponse, []string) {
+		server, paths := newMSATestServer(t, func(path string) string {
+			switch path {
+			case "/api/show/volumes/vol-a":
+				return showVolume
+			case "/api/show/maps/volume/vol-a":
+				return `<RESPONSE VERSION="L100"><OBJECT basetype="volume-view" name="volume-view"><PROPERTY name="volume-name">vol-a</PROPERTY>` +
+					`<OBJECT basetype="volume-view-mappings" name="volume-view-mapping"><PROPERTY name="mapped-id">` + initiator + `</PROPERTY>` +
+					`<PROPERTY name="access">read-write</PROPERTY><PROPERTY name="lun">10</PROPERTY><PROPERTY name="ports">A1,B1</PROPERTY></OBJECT></OBJECT></RESPONSE>`
+			}
+			return `<RESPONSE VERSION="L100"></RESPONSE>`
+		})
+		client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+		if err != nil {
+			t.Fatalf("create client: %v", err)
+		}
+
+		r := &volumeMappingResource{client: client}
+		state := resourceState(t, r, map[string]tftypes.Value{
+			"volume_name":       tftypes.NewValue(tftypes.String, "vol-a"),
+			"target_type":       tftypes.NewValue(tftypes.String, "initiator"),
+			"target_name":       tftypes.NewValue(tftypes.String, initiator),
+			"access":            tftypes.NewValue(tftypes.String, "rw"),
+			"lun":               tftypes.NewValue(tftypes.String, "10"),
+			"skip_volume_check": tftypes.NewValue(tftypes.Bool, skip),
+		})
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: state.Schema, Raw: state.Raw}}
+		r.Create(context.Background(), resource.CreateRequest{Plan: tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}}, &resp)
+		return resp, *paths
+	}
+	mapped := func(paths []string) bool {
+		for _, path := range paths {
+			if strings.HasPrefix(path, "/api/map/volume") {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("present volume is mapped", func(t *testing.T) {
+		resp, paths := create(t, mappingTestVolumeResponse, false)
+		if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 0 {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		if !mapped(paths) {
+			t.Fatalf("expected the volume to be mapped, got %v", paths)
+		}
+	})
+
+	for name, showVolume := range map[string]string{
+		"empty listing": `<RESPONSE VERSION="L100"></RESPONSE>`,
+		"array error":   missingVolume,
+	} {
+		t.Run("missing volume from "+name, func(t *testing.T) {
+			resp, paths := create(t, showVolume, false)
+			if !resp.Diagnostics.HasError() {
+				t.Fatalf("expected a missing volume diagnostic")
+			}
+			diagnostic := resp.Diagnostics.Errors()[0]
+			if diagnostic.Summary() != "Volume not found" || !strings.Contains(diagnostic.Detail(), `volume "vol-a" not found; create hpe_msa_volume first`) {
+				t.Fatalf("unexpected diagnostic: %v", resp.Diagnostics)
+			}
+			if mapped(paths) {
+				t.Fatalf("expected no map command for a missing volume, got %v", paths)
+			}
+		})
+	}
+
+	t.Run("skip_volume_check maps without probing", func(t *testing.T) {
+		resp, paths := create(t, `<RESPONSE VERSION="L100"></RESPONSE>`, true)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+		}
+		// The volume listing stays empty, so the manifest cannot carry its WWN.
+		if warnings := resp.Diagnostics.Warnings(); len(warnings) != 1 || warnings[0].Summary() != "Mapping manifest incomplete" {
+			t.Fatalf("expected a manifest warning, got %v", resp.Diagnostics)
+		}
+		if !mapped(paths) {
+			t.Fatalf("expected the volume to be mapped, got %v", paths)
+		}
+		for _, path := range paths {
+			if strings.HasPrefix(path, "/api/map/volume") {
+				break
+			}
+			if path == "/api/show/volumes/vol-a" {
+				t.Fatalf("expected no pre-flight volume probe, got %v", paths)
+			}
+		}
+	})
+}
+
+func TestVolumeMappingUpdateRotatesConnectionPassword(t *testing.T) {
+	r := &volumeMappingResource{}
+	connectionType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"endpoint":     tftypes.String,
+		"username":     tftypes.String,
+		"password":     tftypes.String,
+		"insecure_tls": tftypes.Bool,
+	}}
+	mappingState := func(password string) tfsdk.State {
+		return resourceState(t, r, map[string]tftypes.Value{
+			"id":          tftypes.NewValue(tftypes.String, "vol-a:host-a.*"),
+			"volume_name": tftypes.NewValue(tftypes.String, "vol-a"),
+			"target_type": tftypes.NewValue(tftypes.String, "host"),
+			"target_name": tftypes.NewValue(tftypes.String, "host-a"),
+			"connection": tftypes.NewValue(connectionType, map[string]tftypes.Value{
+				"endpoint":     tftypes.NewValue(tftypes.String, "https://msa2.example.com"),
+				"username":     tftypes.NewValue(tftypes.String, "manage"),
+				"password":     tftypes.NewValue(tftypes.String, password),
+				"insecure_tls": tftypes.NewValue(tftypes.Bool, nil),
+			}),
+		})
+	}
+	state := mappingState("old-secret")
+	planned := mappingState("new-secret")
+
+	resp := resource.UpdateResponse{State: state}
+	r.Update(context.Background(), resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("expected a password rotation to update in place, got %v", resp.Diagnostics)
+	}
+	var got volumeMappingResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &got)...)
+	if got.Connection == nil || got.Connection.Password.ValueString() != "new-secret" {
+		t.Fatalf("expected the new password in state, got %+v", got.Connection)
+	}
+}
+
+func TestVolumeMappingNicknameLookupFailureKeepsMapping(t *testing.T) {
+	initiatorsError := `<RESPONSE VERSION="L100"><OBJECT basetype="status" name="status"><PROPERTY name="response-type">Error</PROPERTY><PROPERTY name="response-type-numeric">1</PROPERTY>` +
+		`<PROPERTY name="response">Error: The system is busy.</PROPERTY><PROPERTY name="return-code">-3</PROPERTY></OBJECT></RESPONSE>`
+	ambiguous := `<RESPONSE VERSION="L100">` +
+		`<OBJECT basetype="initiator" name="initiator"><PROPERTY name="id">iqn.1991-05.com.example:host-a</PROPERTY><PROPERTY name="nickname">host-a</PROPERTY></OBJECT>` +
+		`<OBJECT basetype="initiator" name="initiator"><PROPERTY name="id">iqn.1991-05.com.example:host-b</PROPERTY><PROPERTY name="nickname">host-a</PROPERTY></OBJECT>` +
+		`</RESPONSE>`
+
+	for name, initiators := range map[string]string{"lookup error": initiatorsError, "ambiguous nickname": ambiguous} {
+		t.Run(name, func(t *testing.T) {
+			server, paths := newMSATestServer(t, func(path string) string {
+				if path == "/api/show/initiators" {
+					return initiators
+				}
+				return `<RESPONSE VERSION="L100"></RESPONSE>`
+			})
+			client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+			if err != nil {
+				t.Fatalf("create client: %v", err)
+			}
+			r := &volumeMappingResource{client: client}
+			state := resourceState(t, r, map[string]tftypes.Value{
+				"id":          tftypes.NewValue(tftypes.String, "vol-a:host-a"),
+				"volume_name": tftypes.NewValue(tftypes.String, "vol-a"),
+				"target_type": tftypes.NewValue(tftypes.String, "initiator"),
+				"target_name": tftypes.NewValue(tftypes.String, "host-a"),
+			})
+
+			readResp := resource.ReadResponse{State: state}
+			r.Read(context.Background(), resource.ReadRequest{State: state}, &readResp)
+			if !readResp.Diagnostics.HasError() || readResp.Diagnostics.Errors()[0].Summary() != "Unable to resolve initiator nickname" {
+				t.Fatalf("expected Read to report the lookup failure, got %v", readResp.Diagnostics)
+			}
+			if readResp.State.Raw.IsNull() {
+				t.Fatalf("expected the mapping to stay in state")
+			}
+
+			deleteResp := resource.DeleteResponse{State: state}
+			r.Delete(context.Background(), resource.DeleteRequest{State: state}, &deleteResp)
+			if !deleteResp.Diagnostics.HasError() {
+				t.Fatalf("expected Delete to report the lookup failure")
+			}
+			for _, path := range *paths {
+				if strings.HasPrefix(path, "/api/unmap/") {
+					t.Fatalf("expected no unmap with an unresolved nickname, got %v", *paths)
+				}
+			}
+		})
+	}
+}
@@ -1,13 +1,20 @@
 package provider
 
 import (
+	"context"
 	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestResolveVolumeTarget(t *testing.T) {
@@ -63,11 +70,78 @@ func TestResolveVolumeTarget(t *testing.T) {
 	}
 }
 
-func stringValueOrNull(value string) types.String {
-	if value == "" {
-		return types.StringNull()
+func TestResolvePlacementName(t *testing.T) {
+	named := func(baseType, property string, names ...string) fakeVolumeDeleteProbeResult {
+		objects := make([]msa.Object, 0, len(names))
+		for _, name := range names {
+			objects = append(objects, msa.Object{
+				BaseType:   baseType,
+				Properties: []msa.Property{{Name: property, Value: name}},
+			})
+		}
+		return fakeVolumeDeleteProbeResult{response: msa.Response{Objects: objects}}
+	}
+	client := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show pools":       named("pools", "name", "A", "B"),
+		"show disk-groups": named("disk-groups", "name", "dgA01", "VD-Linear"),
+	}}
+
+	cases := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{name: "exact pool", input: "A", want: "A"},
+		{name: "pool casing", input: "b", want: "B"},
+		{name: "disk group casing", input: "vd-linear", want: "VD-Linear"},
+		{name: "not found", input: "C", wantErr: errVolumeTargetNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resolvePlacementName(context.Background(), client, tc.input)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				if !strings.Contains(err.Error(), "dgA01") {
+					t.Fatalf("expected available names in error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+
+	ambiguous := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show pools":       named("pools", "name", "A"),
+		"show disk-groups": named("disk-groups", "name", "dg-x", "DG-X"),
+	}}
+	if got, err := resolvePlacementName(context.Background(), ambiguous, "DG-X"); err != nil || got != "DG-X" {
+		t.Fatalf("expected exact match to win, got %q, %v", got, err)
+	}
+	if _, err := resolvePlacementName(context.Background(), ambiguous, "Dg-X"); err == nil {
+		t.Fatalf("expected ambiguous case-insensitive match to fail")
+	}
+	if _, err := resolvePlacementName(context.Background(), fakeVolumeDeleteProbeClient{}, "A"); err == nil || errors.Is(err, errVolumeTargetNotFound) {
+		t.Fatalf("expected query failure to be reported, got %v", err)
+	}
+}
+
+func TestVolumeStateKeepsConfiguredPoolCasing(t *testing.T) {
+	model := volumeResourceModel{Pool: types.StringValue("a"), VDisk: types.StringUnknown()}
+	state := volumeStateFromModel(model, &msa.Volume{Name: "vol01", PoolName: "A", VDiskName: "A"})
+	if state.Pool.ValueString() != "a" {
+		t.Fatalf("expected configured pool casing to be kept, got %q", state.Pool.ValueString())
+	}
+	if state.VDisk.ValueString() != "A" {
+		t.Fatalf("expected computed vdisk from array, got %v", state.VDisk)
 	}
-	return types.StringValue(value)
 }
 
 func TestParseSizeToBytes(t *testing.T) {
@@ -89,6 +163,12 @@ func TestParseSizeToBytes(t *testing.T) {
 		{name: "zero", input: "0GB", wantErr: true},
 		{name: "malformed", input: "1..2GB", wantErr: true},
 		{name: "invalid-unit", input: "1GBB", wantErr: true},
+		{name: "blocks", input: "2097152blocks", want: 1_073_741_824},
+		{name: "blocks-space", input: "2097152 blocks", want: 1_073_741_824},
+		{name: "blk", input: "1BLK", want: 512},
+		{name: "bare-b-is-bytes", input: "2097152b", want: 2_097_152},
+		{name: "zero-blocks", input: "0blocks", wantErr: true},
+		{name: "fractional-blocks", input: "1.5blocks", wantErr: true},
 	}
 
 	for _, tc := range testCases {
@@ -150,6 +230,89 @@ func TestParseSizeToBytesStressInputs(t *testing.T) {
 	}
 }
 
+func TestVolumeCreateCommand(t *testing.T) {
+	got := strings.Join(volumeCreateCommand("vol01", "pool-a", "100GB"), " ")
+	want := "create volume vol01 pool pool-a size 100GB access no-access"
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestVolumeSizeParameter(t *testing.T) {
+	cases := map[string]string{
+		"100GB":          "100GB",
+		"2097152blocks":  "1073741824B",
+		"2097152 blocks": "1073741824B",
+	}
+	for input, want := range cases {
+		got, err := volumeSizeParameter(input)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", input, err)
+		}
+		if got != want {
+			t.Fatalf("expected %q for %q, got %q", want, input, got)
+		}
+	}
+	if _, err := volumeSizeParameter("-5blocks"); err == nil {
+		t.Fatalf("expected non-positive block count to be rejected")
+	}
+}
+
+func TestVolumeSizeMatchesBlocks(t *testing.T) {
+	// 2GB in 512-byte blocks, reported by the array 4 MiB short.
+	volume := &msa.Volume{SizeNumeric: strconv.FormatInt((2_000_000_000-4*1024*1024)/512, 10)}
+	match, err := volumeSizeMatches("3906250blocks", volume)
+	if err != nil || !match {
+		t.Fatalf("expected block size within tolerance to match, got %v, %v", match, err)
+	}
+
+	match, err = volumeSizeMatches("3800000blocks", volume)
+	if err != nil || match {
+		t.Fatalf("expected block size outside tolerance to mismatch, got %v, %v", match, err)
+	}
+
+	// Configured blocks are always 512 bytes, as sent to create volume, even
+	// when the volume reports 4K blocks.
+	volume = &msa.Volume{SizeNumeric: "262144", Properties: map[string]string{"blocksize": "4096"}}
+	match, err = volumeSizeMatches("2097152blocks", volume)
+	if err != nil || !match {
+		t.Fatalf("expected 512-byte blocks to match a 4K-block volume of the same size, got %v, %v", match, err)
+	}
+	match, err = volumeSizeMatches("262144blocks", volume)
+	if err != nil || match {
+		t.Fatalf("expected the 4K block count not to be read as 512-byte blocks, got %v, %v", match, err)
+	}
+}
+
+func TestReadAheadSizeCommand(t *testing.T) {
+	got := strings.Join(readAheadSizeCommand("adaptive", "SN123"), " ")
+	if got != "set volume read-ahead-size adaptive SN123" {
+		t.Fatalf("unexpected command %q", got)
+	}
+}
+
+func TestReadAheadStateValue(t *testing.T) {
+	// The configured spelling is kept when the array reports the same size.
+	if got := readAheadStateValue(types.StringValue("1048576"), "1MB"); got.ValueString() != "1048576" {
+		t.Fatalf("expected configured spelling to be kept, got %v", got)
+	}
+	// Drift is reported in canonical form.
+	if got := readAheadStateValue(types.StringValue("1MB"), "Adaptive"); got.ValueString() != "adaptive" {
+		t.Fatalf("expected drift to adaptive, got %v", got)
+	}
+	// Unset attributes pick up the array value.
+	if got := readAheadStateValue(types.StringUnknown(), "Disabled"); got.ValueString() != "disabled" {
+		t.Fatalf("expected array value, got %v", got)
+	}
+	// Firmware without the property leaves the attribute untouched.
+	if got := readAheadStateValue(types.StringUnknown(), ""); !got.IsNull() {
+		t.Fatalf("expected null when the array does not report read-ahead, got %v", got)
+	}
+	if got := readAheadStateValue(types.StringValue("stripe"), ""); got.ValueString() != "stripe" {
+		t.Fatalf("expected prior value when the array does not report read-ahead, got %v", got)
+	}
+}
+
 func TestVolumeSizeMatches(t *testing.T) {
 	planSize := "2GB"
 	planBytes := int64(2_000_000_000)
@@ -242,6 +405,31 @@ func TestVolumeStateFromModelSCSIWWN(t *testing.T) {
 	}
 }
 
+func TestVolumeStateFromModelAllocation(t *testing.T) {
+	volume := &msa.Volume{
+		Name:         "thin01",
+		SerialNumber: "SN456",
+		Properties: map[string]string{
+			"allocated-size-numeric": "19531250",
+			"blocksize":              "512",
+		},
+	}
+
+	state := volumeStateFromModel(volumeResourceModel{}, volume)
+	if state.AllocatedSize.ValueInt64() != 10_000_000_000 {
+		t.Fatalf("unexpected allocated_size %v", state.AllocatedSize)
+	}
+	if state.AllocatedPages.ValueInt64() != 2385 {
+		t.Fatalf("unexpected allocated_pages %v", state.AllocatedPages)
+	}
+
+	volume.Properties = map[string]string{}
+	state = volumeStateFromModel(volumeResourceModel{}, volume)
+	if !state.AllocatedSize.IsNull() || !state.AllocatedPages.IsNull() {
+		t.Fatalf("expected allocation to be null when not reported")
+	}
+}
+
 func TestClassifyVolumeDeleteErrorMapped(t *testing.T) {
 	err := msa.APIError{
 		Status: msa.Status{
@@ -301,3 +489,100 @@ func TestClassifyVolumeDeleteErrorNoMatch(t *testing.T) {
 		t.Fatalf("did not expect guardrail for non-API error")
 	}
 }
+
+func TestVolumeDeleteReleasesLockWhenCancelled(t *testing.T) {
+	lockDir := filepath.Join(t.TempDir(), "destroy-lock.d")
+	t.Setenv("HPE_MSA_DESTROY_GLOBAL_LOCK_DIR", lockDir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var lockHeld atomic.Bool
+	server, _ := newMSATestServer(t, func(path string) string {
+		if strings.HasPrefix(path, "/api/delete/volumes") {
+			_, err := os.Stat(lockDir)
+			lockHeld.Store(err == nil)
+			cancel()
+		}
+		return `<RESPONSE VERSION="L100"></RESPONSE>`
+	})
+	client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	r := &volumeResource{client: client}
+	state := resourceState(t, r, map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, "SN-A"),
+		"name":          tftypes.NewValue(tftypes.String, "vol-a"),
+		"size":          tftypes.NewValue(tftypes.String, "10GB"),
+		"allow_destroy": tftypes.NewValue(tftypes.Bool, true),
+	})
+
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+	if !lockHeld.Load() {
+		t.Fatalf("expected delete to run while holding the destroy lock")
+	}
+	if _, err := os.Stat(lockDir); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("lock should be released after cancellation, stat err=%v", err)
+	}
+}
+
+func TestVolumeExistsAfterLocalizedCreateError(t *testing.T) {
+	client := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show volumes vol01": {response: msa.Response{Objects: []msa.Object{{
+			BaseType:   "volumes",
+			Properties: []msa.Property{{Name: "volume-name", Value: "vol01"}},
+		}}}},
+		"show volumes vol02": {response: msa.Response{}},
+	}}
+
+	if !volumeExists(context.Background(), client, "vol01") {
+		t.Fatalf("expected vol01 to be found regardless of the create error text")
+	}
+	if volumeExists(context.Background(), client, "vol02") {
+		t.Fatalf("expected vol02 to be absent")
+	}
+	if volumeExists(context.Background(), client, "vol03") {
+		t.Fatalf("expected lookup errors to count as absent")
+	}
+}
+
+func TestUnexpectedVolumeMappingsAfterCreate(t *testing.T) {
+	volumeView := func(access, lun string) fakeVolumeDeleteProbeResult {
+		return fakeVolumeDeleteProbeResult{response: msa.Response{Objects: []msa.Object{{
+			BaseType:   "volume-view",
+			Properties: []msa.Property{{Name: "volume-name", Value: "vol01"}},
+			Objects: []msa.Object{{
+				BaseType: "volume-view-mappings",
+				Properties: []msa.Property{
+					{Name: "mapped-id", Value: "all other initiators"},
+					{Name: "access", Value: access},
+					{Name: "lun", Value: lun},
+					{Name: "ports", Value: "A1,B1"},
+				},
+			}},
+		}}}}
+	}
+	volume := &msa.Volume{Name: "vol01", SerialNumber: "SN-1"}
+
+	mapped := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show maps volume vol01": volumeView("read-write", "0"),
+	}}
+	summary, detail, ok := unexpectedVolumeMappings(context.Background(), mapped, volume)
+	if !ok || summary != "Volume mapped right after create" {
+		t.Fatalf("expected a default mapping warning, got %q/%q", summary, detail)
+	}
+	if !strings.Contains(detail, "1 mapping") || !strings.Contains(detail, "show maps volume vol01") {
+		t.Fatalf("expected count and command in detail, got %q", detail)
+	}
+
+	unmapped := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show maps volume vol01": volumeView("no-access", ""),
+		"show maps volume SN-1":  {response: msa.Response{}},
+		"show maps":              {response: msa.Response{}},
+	}}
+	if summary, detail, ok := unexpectedVolumeMappings(context.Background(), unmapped, volume); ok {
+		t.Fatalf("expected no warning for a no-access row, got %q/%q", summary, detail)
+	}
+}
@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+)
+
+// recordingCommandClient records every command it is sent and returns the
+// queued error for that command line, or success once the queue is empty.
+type recordingCommandClient struct {
+	results map[string][]error
+	calls   []string
+}
+
+func (c *recordingCommandClient) Execute(_ context.Context, parts ...string) (msa.Response, error) {
+	key := strings.Join(parts, " ")
+	c.calls = append(c.calls, key)
+	queue := c.results[key]
+	if len(queue) == 0 {
+		return msa.Response{}, nil
+	}
+	err := queue[0]
+	c.results[key] = queue[1:]
+	return msa.Response{}, err
+}
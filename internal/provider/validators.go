@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -273,3 +274,108 @@ func validateHostGroupNameValue(value string) error {
 	}
 	return nil
 }
+
+var knownHostProfiles = []string{"standard", "hp-ux", "openvms"}
+
+type hostProfileValidator struct{}
+
+func (v hostProfileValidator) Description(_ context.Context) string {
+	return "Host profile must be one of standard, hp-ux, or openvms."
+}
+
+func (v hostProfileValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v hostProfileValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if !isKnownHostProfile(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid host profile",
+			"profile must be one of standard, hp-ux, or openvms.",
+		)
+	}
+}
+
+func isKnownHostProfile(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, profile := range knownHostProfiles {
+		if value == profile {
+			return true
+		}
+	}
+	return false
+}
+
+// readAheadSizes are the fixed read-ahead sizes the array accepts, in bytes,
+// keyed by their canonical CLI spelling.
+var readAheadSizes = []struct {
+	name  string
+	bytes int64
+}{
+	{"64KB", 64 << 10},
+	{"128KB", 128 << 10},
+	{"256KB", 256 << 10},
+	{"512KB", 512 << 10},
+	{"1MB", 1 << 20},
+	{"2MB", 2 << 20},
+	{"4MB", 4 << 20},
+	{"8MB", 8 << 20},
+	{"16MB", 16 << 20},
+	{"32MB", 32 << 20},
+}
+
+type readAheadSizeValidator struct{}
+
+func (v readAheadSizeValidator) Description(_ context.Context) string {
+	return "Read-ahead size must be adaptive, disabled, stripe, or one of 64KB, 128KB, 256KB, 512KB, 1MB, 2MB, 4MB, 8MB, 16MB, 32MB (or the same size in bytes)."
+}
+
+func (v readAheadSizeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v readAheadSizeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	if _, err := normalizeReadAheadSize(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid read-ahead size", v.Description(ctx))
+	}
+}
+
+// normalizeReadAheadSize maps the accepted spellings of a read-ahead setting
+// to the value passed to the CLI: a lower-case mode or an upper-case size.
+// Sizes are binary (1MB = 1048576 bytes) and may be given as a byte count.
+func normalizeReadAheadSize(value string) (string, error) {
+	trimmed := strings.TrimSpace(value)
+	lower := strings.ToLower(trimmed)
+	switch lower {
+	case "adaptive", "disabled", "stripe":
+		return lower, nil
+	}
+
+	compact := strings.ToUpper(strings.ReplaceAll(trimmed, " ", ""))
+	compact = strings.Replace(compact, "IB", "B", 1)
+	if isDigits(compact) {
+		bytes, err := strconv.ParseInt(compact, 10, 64)
+		if err == nil {
+			for _, size := range readAheadSizes {
+				if size.bytes == bytes {
+					return size.name, nil
+				}
+			}
+		}
+	}
+	for _, size := range readAheadSizes {
+		if compact == size.name {
+			return size.name, nil
+		}
+	}
+	return "", fmt.Errorf("invalid read-ahead size %q", value)
+}
@@ -119,6 +119,28 @@ func TestHostGroupNameValidator(t *testing.T) {
 	}
 }
 
+func TestHostProfileValidator(t *testing.T) {
+	v := hostProfileValidator{}
+
+	for _, value := range []string{"standard", "Standard", "HP-UX", "openvms"} {
+		req := validator.StringRequest{ConfigValue: types.StringValue(value)}
+		resp := &validator.StringResponse{}
+		v.ValidateString(context.Background(), req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diagnostics for valid profile %q: %v", value, resp.Diagnostics)
+		}
+	}
+
+	for _, value := range []string{"", "windows", "hpux"} {
+		req := validator.StringRequest{ConfigValue: types.StringValue(value)}
+		resp := &validator.StringResponse{}
+		v.ValidateString(context.Background(), req, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Fatalf("expected diagnostics for invalid profile %q", value)
+		}
+	}
+}
+
 func TestHostNamesSetValidator(t *testing.T) {
 	v := hostNamesSetValidator{}
 
@@ -162,3 +184,27 @@ func TestValidateHostGroupNameValue(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalizeReadAheadSize(t *testing.T) {
+	valid := map[string]string{
+		"Adaptive": "adaptive",
+		"disabled": "disabled",
+		" STRIPE ": "stripe",
+		"1mb":      "1MB",
+		"512 KiB":  "512KB",
+		"32MB":     "32MB",
+		"1048576":  "1MB",
+	}
+	for input, want := range valid {
+		got, err := normalizeReadAheadSize(input)
+		if err != nil || got != want {
+			t.Fatalf("normalizeReadAheadSize(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	for _, input := range []string{"", "auto", "3MB", "64MB", "1000", "-1"} {
+		if _, err := normalizeReadAheadSize(input); err == nil {
+			t.Fatalf("expected %q to be rejected", input)
+		}
+	}
+}
@@ -123,6 +123,10 @@ func preDeleteVolumeUsageGuardrail(ctx context.Context, client volumeDeleteProbe
 	return volumeDeleteGuardrail{}, false
 }
 
+// probeVolumeMappings counts the mappings of the volume in both the
+// initiator-keyed and volume-keyed map views. Explicit no-access rows, such
+// as the "all other initiators" default, present nothing to hosts and are
+// not counted.
 func probeVolumeMappings(ctx context.Context, client volumeDeleteProbeClient, identities []string) (int, string, error) {
 	commands := make([][]string, 0, len(identities)+1)
 	for _, identity := range identities {
@@ -142,7 +146,13 @@ func probeVolumeMappings(ctx context.Context, client volumeDeleteProbeClient, id
 		}
 
 		count := 0
-		for _, mapping := range msa.MappingsFromResponse(response) {
+		// Both parsers can report the same row, and some firmware lists a
+		// mapping once per controller; count each logical mapping once.
+		mappings := msa.DedupeMappings(append(msa.MappingsFromResponse(response), msa.MappingsByVolumeFromResponse(response)...))
+		for _, mapping := range mappings {
+			if strings.EqualFold(strings.TrimSpace(mapping.Access), "no-access") {
+				continue
+			}
 			if volumeIdentityMatches(mapping.Volume, identities) || volumeIdentityMatches(mapping.VolumeSerial, identities) {
 				count++
 			}
@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -209,3 +211,61 @@ func TestClassifyVolumeDeleteErrorActiveSessionsRetryable(t *testing.T) {
 		t.Fatalf("expected retryable classification, got %s", guardrail.detail)
 	}
 }
+
+func TestProbeVolumeMappingsCountsLogicalMappingsOnce(t *testing.T) {
+	fixture, err := os.ReadFile(filepath.Join("..", "msa", "testdata", "show_maps_duplicated.xml"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	server, _ := newMSATestServer(t, func(path string) string {
+		if path == "/api/show/maps/volume/volB" {
+			return string(fixture)
+		}
+		return `<RESPONSE VERSION="L100"></RESPONSE>`
+	})
+	client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+
+	count, command, err := probeVolumeMappings(context.Background(), client, []string{"volB"})
+	if err != nil {
+		t.Fatalf("probe mappings: %v", err)
+	}
+	if count != 2 || command != "show maps volume volB" {
+		t.Fatalf("expected 2 logical mappings via show maps volume volB, got %d via %q", count, command)
+	}
+}
+
+func TestPreDeleteGuardrailIgnoresNoAccessDefaultRow(t *testing.T) {
+	volumeView := func(access, lun string) fakeVolumeDeleteProbeResult {
+		return fakeVolumeDeleteProbeResult{response: msa.Response{Objects: []msa.Object{{
+			BaseType:   "volume-view",
+			Properties: []msa.Property{{Name: "volume-name", Value: "vol01"}},
+			Objects: []msa.Object{{
+				BaseType: "volume-view-mappings",
+				Properties: []msa.Property{
+					{Name: "mapped-id", Value: "all other initiators"},
+					{Name: "access", Value: access},
+					{Name: "lun", Value: lun},
+					{Name: "ports", Value: "A1,B1"},
+				},
+			}},
+		}}}}
+	}
+
+	unmapped := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show maps volume vol01": volumeView("no-access", ""),
+	}}
+	if guardrail, ok := preDeleteVolumeUsageGuardrail(context.Background(), unmapped, "volume", "vol01"); ok {
+		t.Fatalf("expected a no-access default row not to block the delete, got %+v", guardrail)
+	}
+
+	mapped := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show maps volume vol01": volumeView("read-write", "0"),
+	}}
+	guardrail, ok := preDeleteVolumeUsageGuardrail(context.Background(), mapped, "volume", "vol01")
+	if !ok || !strings.Contains(guardrail.summary, "mapped") {
+		t.Fatalf("expected a presented mapping to block the delete, got %+v", guardrail)
+	}
+}
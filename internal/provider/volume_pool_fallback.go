@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// isInsufficientSpaceError reports whether the array rejected a create
+// because the pool does not have enough free capacity.
+func isInsufficientSpaceError(err error) bool {
+	var apiErr msa.APIError
+	if !errors.As(err, &apiErr) || apiErr.PermissionDenied {
+		return false
+	}
+
+	msg := strings.ToLower(apiErr.Status.Response)
+	return containsAny(msg,
+		"insufficient space",
+		"insufficient free space",
+		"insufficient capacity",
+		"enough space",
+		"enough free space",
+		"enough available space",
+		"exceeds the available space",
+		"exceeds available space",
+		"out of space",
+		"no space available",
+	)
+}
+
+// createVolumeInPools runs `create volume` in each pool in order, moving to
+// the next pool only when the array reports insufficient space. It returns
+// the pool of the last attempt together with its error.
+func createVolumeInPools(ctx context.Context, client commandExecutor, name, size string, pools []string) (string, error) {
+	var pool string
+	var err error
+	for i, candidate := range pools {
+		pool = candidate
+		_, err = client.Execute(ctx, volumeCreateCommand(name, pool, size)...)
+		if err == nil || !isInsufficientSpaceError(err) || i == len(pools)-1 {
+			return pool, err
+		}
+		tflog.Warn(ctx, "insufficient space for volume; trying the next fallback pool", map[string]any{
+			"volume": name,
+			"pool":   pool,
+			"next":   pools[i+1],
+			"error":  err.Error(),
+		})
+	}
+	return pool, err
+}
+
+// volumeCreatePools resolves fallback_pools against the array and returns
+// the pools to try, starting with target. Duplicates are dropped.
+func volumeCreatePools(ctx context.Context, client commandExecutor, target string, fallbacks []string) ([]string, error) {
+	pools := []string{target}
+	for _, fallback := range fallbacks {
+		resolved, err := resolvePlacementName(ctx, client, fallback)
+		if err != nil {
+			return nil, err
+		}
+		if !containsFold(pools, resolved) {
+			pools = append(pools, resolved)
+		}
+	}
+	return pools, nil
+}
+
+func fallbackPoolsFromModel(value types.List) []string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	pools := make([]string, 0, len(value.Elements()))
+	for _, element := range value.Elements() {
+		pool, ok := element.(types.String)
+		if !ok || pool.IsNull() || pool.IsUnknown() {
+			continue
+		}
+		if trimmed := strings.TrimSpace(pool.ValueString()); trimmed != "" {
+			pools = append(pools, trimmed)
+		}
+	}
+	return pools
+}
+
+func containsFold(values []string, candidate string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, candidate) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/d3vi1/tf-provider-hpe-msa/internal/msa"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestIsInsufficientSpaceError(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "insufficient free space", err: limitAPIError("Error: Insufficient free space in the pool."), want: true},
+		{name: "not enough space", err: limitAPIError("Error: The specified pool does not have enough space for the volume."), want: true},
+		{name: "exceeds available", err: limitAPIError("Error: The size exceeds the available space."), want: true},
+		{name: "volume limit", err: limitAPIError("Error: The maximum number of volumes for the system has been reached."), want: false},
+		{name: "name in use", err: limitAPIError("Error: The name is already in use."), want: false},
+		{name: "permission denied", err: msa.APIError{Status: msa.Status{Response: "Insufficient space: permission denied"}, PermissionDenied: true}, want: false},
+		{name: "transport error", err: errors.New("insufficient space"), want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isInsufficientSpaceError(tc.err); got != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCreateVolumeInPools(t *testing.T) {
+	full := limitAPIError("Error: Insufficient free space in the pool.")
+	create := func(pool string) string {
+		return strings.Join(volumeCreateCommand("vol01", pool, "100GB"), " ")
+	}
+
+	t.Run("first pool succeeds", func(t *testing.T) {
+		client := &recordingCommandClient{}
+		pool, err := createVolumeInPools(context.Background(), client, "vol01", "100GB", []string{"A", "B"})
+		if err != nil || pool != "A" || len(client.calls) != 1 {
+			t.Fatalf("expected a single create in A, got pool=%q err=%v commands=%v", pool, err, client.calls)
+		}
+	})
+
+	t.Run("falls back in order", func(t *testing.T) {
+		client := &recordingCommandClient{results: map[string][]error{create("A"): {full}, create("B"): {full}}}
+		pool, err := createVolumeInPools(context.Background(), client, "vol01", "100GB", []string{"A", "B", "C"})
+		if err != nil || pool != "C" {
+			t.Fatalf("expected the volume in C, got pool=%q err=%v", pool, err)
+		}
+		want := []string{create("A"), create("B"), create("C")}
+		if strings.Join(client.calls, "|") != strings.Join(want, "|") {
+			t.Fatalf("expected %v, got %v", want, client.calls)
+		}
+	})
+
+	t.Run("last pool error is returned", func(t *testing.T) {
+		client := &recordingCommandClient{results: map[string][]error{create("A"): {full}, create("B"): {full}}}
+		pool, err := createVolumeInPools(context.Background(), client, "vol01", "100GB", []string{"A", "B"})
+		if pool != "B" || !isInsufficientSpaceError(err) {
+			t.Fatalf("expected the space error from B, got pool=%q err=%v", pool, err)
+		}
+	})
+
+	t.Run("other errors stop the iteration", func(t *testing.T) {
+		limit := limitAPIError("Error: The maximum number of volumes for the system has been reached.")
+		client := &recordingCommandClient{results: map[string][]error{create("A"): {limit}}}
+		pool, err := createVolumeInPools(context.Background(), client, "vol01", "100GB", []string{"A", "B"})
+		if pool != "A" || !errors.Is(err, limit) || len(client.calls) != 1 {
+			t.Fatalf("expected no fallback after a non-space error, got pool=%q err=%v commands=%v", pool, err, client.calls)
+		}
+	})
+}
+
+func TestVolumeCreatePools(t *testing.T) {
+	client := fakeVolumeDeleteProbeClient{results: map[string]fakeVolumeDeleteProbeResult{
+		"show pools": {response: msa.Response{Objects: []msa.Object{
+			{BaseType: "pools", Properties: []msa.Property{{Name: "name", Value: "A"}}},
+			{BaseType: "pools", Properties: []msa.Property{{Name: "name", Value: "B"}}},
+		}}},
+		"show disk-groups": {response: msa.Response{}},
+	}}
+
+	pools, err := volumeCreatePools(context.Background(), client, "A", []string{"b", "a", "B"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(pools, ",") != "A,B" {
+		t.Fatalf("expected resolved, deduplicated pools A,B, got %v", pools)
+	}
+
+	if _, err := volumeCreatePools(context.Background(), client, "A", []string{"C"}); !errors.Is(err, errVolumeTargetNotFound) {
+		t.Fatalf("expected an unknown fallback pool to be rejected, got %v", err)
+	}
+}
+
+func TestVolumeStateKeepsConfiguredPoolAfterFallback(t *testing.T) {
+	fallbacks := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("B")})
+	model := volumeResourceModel{Pool: types.StringValue("A"), VDisk: types.StringUnknown(), FallbackPools: fallbacks, ActualPool: types.StringUnknown()}
+
+	state := volumeStateFromModel(model, &msa.Volume{Name: "vol01", PoolName: "B", VDiskName: "B"})
+	if state.Pool.ValueString() != "A" {
+		t.Fatalf("expected configured pool to be kept, got %q", state.Pool.ValueString())
+	}
+	if state.ActualPool.ValueString() != "B" {
+		t.Fatalf("expected actual_pool B, got %v", state.ActualPool)
+	}
+
+	state = volumeStateFromModel(model, &msa.Volume{Name: "vol01", PoolName: "C"})
+	if state.Pool.ValueString() != "C" {
+		t.Fatalf("expected a volume outside its pools to report the array's pool, got %q", state.Pool.ValueString())
+	}
+}
+
+func TestVolumeUpdateKeepsPoolAfterFallbackPoolsRemoved(t *testing.T) {
+	server, _ := newMSATestServer(t, func(path string) string {
+		if path == "/api/show/volumes" {
+			return `<RESPONSE VERSION="L100"><OBJECT basetype="volumes" name="volume"><PROPERTY name="volume-name">vol01</PROPERTY><PROPERTY name="serial-number">SN1</PROPERTY><PROPERTY name="storage-pool-name">B</PROPERTY></OBJECT></RESPONSE>`
+		}
+		return `<RESPONSE VERSION="L100"></RESPONSE>`
+	})
+	client, err := msa.NewClient(msa.Config{Endpoint: server.URL, Username: "user", Password: "pass", InsecureTLS: true})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	r := &volumeResource{client: client}
+
+	values := func(fallbacks tftypes.Value) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"id":             tftypes.NewValue(tftypes.String, "SN1"),
+			"name":           tftypes.NewValue(tftypes.String, "vol01"),
+			"size":           tftypes.NewValue(tftypes.String, "10GB"),
+			"pool":           tftypes.NewValue(tftypes.String, "A"),
+			"fallback_pools": fallbacks,
+			"actual_pool":    tftypes.NewValue(tftypes.String, "B"),
+		}
+	}
+	listType := tftypes.List{ElementType: tftypes.String}
+	state := resourceState(t, r, values(tftypes.NewValue(listType, []tftypes.Value{tftypes.NewValue(tftypes.String, "B")})))
+	planned := resourceState(t, r, values(tftypes.NewValue(listType, nil)))
+
+	resp := resource.UpdateResponse{State: state}
+	r.Update(context.Background(), resource.UpdateRequest{Plan: tfsdk.Plan{Schema: planned.Schema, Raw: planned.Raw}, State: state}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	var got volumeResourceModel
+	resp.Diagnostics.Append(resp.State.Get(context.Background(), &got)...)
+	if got.Pool.ValueString() != "A" || got.ActualPool.ValueString() != "B" {
+		t.Fatalf("expected pool A to be kept with actual_pool B, got pool=%v actual_pool=%v", got.Pool, got.ActualPool)
+	}
+
+	readResp := resource.ReadResponse{State: resp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: resp.State}, &readResp)
+	readResp.Diagnostics.Append(readResp.State.Get(context.Background(), &got)...)
+	if readResp.Diagnostics.HasError() || got.Pool.ValueString() != "A" {
+		t.Fatalf("expected Read to keep pool A, got %v (%v)", got.Pool, readResp.Diagnostics)
+	}
+}